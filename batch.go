@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// maxBatchVertices is the maximum number of vertices in one batch, limited by the uint16 indices.
+const maxBatchVertices = 1 << 16
+
+var (
+	// theAtlas is an image that has a white region for rectangles and all the icons,
+	// so that rectangles and icons can be drawn in one DrawTriangles call.
+	theAtlas      *ebiten.Image
+	atlasRects    map[Icon]image.Rectangle
+	atlasWhite    = image.Rect(1, 1, 2, 2)
+	atlasInitOnce sync.Once
+)
+
+func atlas() (*ebiten.Image, map[Icon]image.Rectangle) {
+	atlasInitOnce.Do(func() {
+		icons := []Icon{IconClose, IconCheck, IconCollapsed, IconExpanded}
+
+		// the white region is 3x3 so that the center pixel is not affected by the neighbors.
+		w, h := 3, 3
+		for _, icon := range icons {
+			b := icon.Image().Bounds()
+			w += b.Dx() + 1
+			h = max(h, b.Dy())
+		}
+
+		img := image.NewRGBA(image.Rect(0, 0, w, h))
+		draw.Draw(img, image.Rect(0, 0, 3, 3), image.NewUniform(color.White), image.Point{}, draw.Src)
+		atlasRects = map[Icon]image.Rectangle{}
+		x := 4
+		for _, icon := range icons {
+			src := icon.Image()
+			b := src.Bounds()
+			r := image.Rect(x, 0, x+b.Dx(), b.Dy())
+			draw.Draw(img, r, src, b.Min, draw.Src)
+			atlasRects[icon] = r
+			x += b.Dx() + 1
+		}
+		theAtlas = ebiten.NewImageFromImage(img)
+	})
+	return theAtlas, atlasRects
+}
+
+// batch accumulates rectangles and icons that share a clipping region and draws them with one DrawTriangles call.
+type batch struct {
+	vertices []ebiten.Vertex
+	indices  []uint16
+
+	// draws is the number of the DrawTriangles calls.
+	draws int
+}
+
+func (b *batch) appendQuad(dst, src image.Rectangle, clr color.RGBA) {
+	cr, cg, cb, ca := float32(clr.R)/0xff, float32(clr.G)/0xff, float32(clr.B)/0xff, float32(clr.A)/0xff
+	idx := uint16(len(b.vertices))
+	for _, p := range [...][2]image.Point{
+		{dst.Min, src.Min},
+		{image.Pt(dst.Max.X, dst.Min.Y), image.Pt(src.Max.X, src.Min.Y)},
+		{image.Pt(dst.Min.X, dst.Max.Y), image.Pt(src.Min.X, src.Max.Y)},
+		{dst.Max, src.Max},
+	} {
+		b.vertices = append(b.vertices, ebiten.Vertex{
+			DstX:   float32(p[0].X),
+			DstY:   float32(p[0].Y),
+			SrcX:   float32(p[1].X),
+			SrcY:   float32(p[1].Y),
+			ColorR: cr,
+			ColorG: cg,
+			ColorB: cb,
+			ColorA: ca,
+		})
+	}
+	b.indices = append(b.indices, idx, idx+1, idx+2, idx+1, idx+3, idx+2)
+}
+
+func (b *batch) full() bool {
+	return len(b.vertices)+4 > maxBatchVertices
+}
+
+// flush draws the accumulated quads onto target.
+func (b *batch) flush(target *ebiten.Image) {
+	if len(b.indices) == 0 {
+		return
+	}
+	img, _ := atlas()
+	op := &ebiten.DrawTrianglesOptions{}
+	op.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
+	target.DrawTriangles(b.vertices, b.indices, img, op)
+	b.draws++
+	b.vertices = b.vertices[:0]
+	b.indices = b.indices[:0]
+}
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"fmt"
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// benchmarkFrame does a representative frame with several windows full of rectangles and icons.
+func benchmarkFrame(ctx *Context) {
+	for w := 0; w < 4; w++ {
+		ctx.Window(fmt.Sprintf("Window %d", w), image.Rect(w*160, 0, w*160+150, 400), func(res Response, layout Layout) {
+			ctx.SetLayoutRow([]int{20, 20, 20, -1}, 0)
+			for i := 0; i < 64; i++ {
+				ctx.Control(0, 0, func(r image.Rectangle) Response {
+					ctx.drawFrame(r, ColorButton)
+					ctx.drawIcon(IconCheck, r, ctx.style.colors[ColorText])
+					return 0
+				})
+			}
+		})
+	}
+}
+
+// unbatchedRenderer draws the commands one by one as EbitenRenderer did before the batching:
+// a rectangle with vector.DrawFilledRect and an icon with DrawImage of its own image.
+type unbatchedRenderer struct {
+	target *ebiten.Image
+	icons  map[Icon]*ebiten.Image
+	draws  int
+}
+
+func (r *unbatchedRenderer) Render(commands *CommandIterator) {
+	target := r.target
+	for commands.Next() {
+		cmd := commands.Command()
+		switch cmd.Type() {
+		case CommandRect:
+			rect := cmd.Rect()
+			vector.DrawFilledRect(target, float32(rect.Min.X), float32(rect.Min.Y), float32(rect.Dx()), float32(rect.Dy()), cmd.Color(), false)
+			r.draws++
+		case CommandText:
+			op := &text.DrawOptions{}
+			op.GeoM.Translate(float64(cmd.Pos().X), float64(cmd.Pos().Y))
+			op.ColorScale.ScaleWithColor(cmd.Color())
+			text.Draw(target, cmd.Text(), cmd.Face(), op)
+		case CommandIcon:
+			img, ok := r.icons[cmd.Icon()]
+			if !ok {
+				img = ebiten.NewImageFromImage(cmd.Icon().Image())
+				r.icons[cmd.Icon()] = img
+			}
+			rect := cmd.Rect()
+			op := &ebiten.DrawImageOptions{}
+			x := rect.Min.X + (rect.Dx()-img.Bounds().Dx())/2
+			y := rect.Min.Y + (rect.Dy()-img.Bounds().Dy())/2
+			op.GeoM.Translate(float64(x), float64(y))
+			op.ColorScale.ScaleWithColor(cmd.Color())
+			target.DrawImage(img, op)
+			r.draws++
+		case CommandDraw:
+			cmd.DrawFunc()(target)
+		case CommandClip:
+			target = r.target.SubImage(cmd.Rect()).(*ebiten.Image)
+		}
+	}
+}
+
+// BenchmarkRenderBatched renders a frame with EbitenRenderer.
+//
+// The benchmarks run outside RunGame, so they measure the CPU cost of issuing the draw calls
+// and report the number of the draw calls of the rectangles and the icons. The GPU time is not measured.
+func BenchmarkRenderBatched(b *testing.B) {
+	d := newTestUI(newTestInput())
+	d.Update(benchmarkFrame)
+
+	r := &EbitenRenderer{Target: ebiten.NewImage(640, 480)}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.Render(r)
+	}
+	b.ReportMetric(float64(r.batch.draws)/float64(b.N), "draws/frame")
+}
+
+// BenchmarkRenderUnbatched renders the same frame as BenchmarkRenderBatched with unbatchedRenderer.
+func BenchmarkRenderUnbatched(b *testing.B) {
+	d := newTestUI(newTestInput())
+	d.Update(benchmarkFrame)
+
+	r := &unbatchedRenderer{
+		target: ebiten.NewImage(640, 480),
+		icons:  map[Icon]*ebiten.Image{},
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.Render(r)
+	}
+	b.ReportMetric(float64(r.draws)/float64(b.N), "draws/frame")
+}
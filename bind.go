@@ -4,132 +4,267 @@
 package debugui
 
 import (
-	"bytes"
-	"embed"
 	"image"
-	"sync"
+	"image/color"
+	"math"
 
-	"github.com/hajimehoshi/bitmapfont/v3"
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
-	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
-var fontFace = text.NewGoXFace(bitmapfont.Face)
+// ebitenInputSource is the default InputSource that reads the input from Ebitengine.
+type ebitenInputSource struct {
+	gamepadIDs []ebiten.GamepadID
+}
 
-func DrawText(dst *ebiten.Image, str string, op *text.DrawOptions) {
-	text.Draw(dst, str, fontFace, op)
+func (*ebitenInputSource) CursorPosition() (x, y int) {
+	return ebiten.CursorPosition()
 }
 
-func textWidth(str string) int {
-	return int(text.Advance(str, fontFace))
+func (*ebitenInputSource) Wheel() (xoff, yoff float64) {
+	return ebiten.Wheel()
 }
 
-func lineHeight() int {
-	return int(fontFace.Metrics().HAscent + fontFace.Metrics().HDescent + fontFace.Metrics().HLineGap)
+func (*ebitenInputSource) IsMouseButtonPressed(button ebiten.MouseButton) bool {
+	return ebiten.IsMouseButtonPressed(button)
 }
 
-var (
-	//go:embed icon/*.png
-	iconFS  embed.FS
-	iconMap = map[icon]*ebiten.Image{}
-	iconM   sync.Mutex
-)
+func (*ebitenInputSource) IsKeyPressed(key ebiten.Key) bool {
+	return ebiten.IsKeyPressed(key)
+}
+
+func (*ebitenInputSource) AppendInputChars(runes []rune) []rune {
+	return ebiten.AppendInputChars(runes)
+}
+
+func (*ebitenInputSource) AppendTouchIDs(touches []ebiten.TouchID) []ebiten.TouchID {
+	return ebiten.AppendTouchIDs(touches)
+}
 
-func iconImage(icon icon) *ebiten.Image {
-	iconM.Lock()
-	defer iconM.Unlock()
-
-	if img, ok := iconMap[icon]; ok {
-		return img
-	}
-
-	var name string
-	switch icon {
-	case iconCheck:
-		name = "check.png"
-	case iconClose:
-		name = "close.png"
-	case iconCollapsed:
-		name = "collapsed.png"
-	case iconExpanded:
-		name = "expanded.png"
-	default:
-		return nil
-	}
-	b, err := iconFS.ReadFile("icon/" + name)
-	if err != nil {
-		panic(err)
-	}
-	img, _, err := image.Decode(bytes.NewReader(b))
-	if err != nil {
-		panic(err)
-	}
-	iconMap[icon] = ebiten.NewImageFromImage(img)
-	return iconMap[icon]
-}
-
-func (c *Context) updateInput() {
-	cx, cy := ebiten.CursorPosition()
-	c.inputMouseMove(cx, cy)
-	if wx, wy := ebiten.Wheel(); wx != 0 || wy != 0 {
-		c.inputScroll(int(wx*-30), int(wy*-30))
-	}
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		c.inputMouseDown(cx, cy, ebiten.MouseButtonLeft)
-	} else if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
-		c.inputMouseUp(cx, cy, ebiten.MouseButtonLeft)
-	}
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
-		c.inputMouseDown(cx, cy, ebiten.MouseButtonRight)
-	} else if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonRight) {
-		c.inputMouseUp(cx, cy, ebiten.MouseButtonRight)
-	}
-	for _, k := range []ebiten.Key{ebiten.KeyAlt, ebiten.KeyBackspace, ebiten.KeyControl, ebiten.KeyEnter, ebiten.KeyShift} {
-		if inpututil.IsKeyJustPressed(k) {
-			c.inputKeyDown(k)
-		} else if inpututil.IsKeyJustReleased(k) {
-			c.inputKeyUp(k)
+func (*ebitenInputSource) TouchPosition(id ebiten.TouchID) (x, y int) {
+	return ebiten.TouchPosition(id)
+}
+
+// gamepadID returns the first gamepad in the standard layout.
+func (e *ebitenInputSource) gamepadID() (ebiten.GamepadID, bool) {
+	e.gamepadIDs = ebiten.AppendGamepadIDs(e.gamepadIDs[:0])
+	for _, id := range e.gamepadIDs {
+		if ebiten.IsStandardGamepadLayoutAvailable(id) {
+			return id, true
 		}
 	}
+	return 0, false
+}
+
+func (e *ebitenInputSource) IsStandardGamepadButtonPressed(button ebiten.StandardGamepadButton) bool {
+	id, ok := e.gamepadID()
+	if !ok {
+		return false
+	}
+	return ebiten.IsStandardGamepadButtonPressed(id, button)
+}
+
+func (e *ebitenInputSource) StandardGamepadAxisValue(axis ebiten.StandardGamepadAxis) float64 {
+	id, ok := e.gamepadID()
+	if !ok {
+		return 0
+	}
+	return ebiten.StandardGamepadAxisValue(id, axis)
+}
+
+func (c *Context) setTransform(geoM ebiten.GeoM) {
+	c.transform = geoM
+	c.transformed = true
+	c.inverse = geoM
+	c.invertible = geoM.IsInvertible()
+	if c.invertible {
+		c.inverse.Invert()
+	}
 }
 
 func (c *Context) draw(screen *ebiten.Image) {
-	target := screen
-	var cmd *command
-	for c.nextCommand(&cmd) {
-		switch cmd.typ {
-		case commandRect:
-			vector.DrawFilledRect(
-				target,
-				float32(cmd.rect.rect.Min.X),
-				float32(cmd.rect.rect.Min.Y),
-				float32(cmd.rect.rect.Dx()),
-				float32(cmd.rect.rect.Dy()),
-				cmd.rect.color,
-				false,
-			)
-		case commandText:
-			op := &text.DrawOptions{}
-			op.GeoM.Translate(float64(cmd.text.pos.X), float64(cmd.text.pos.Y))
-			op.ColorScale.ScaleWithColor(cmd.text.color)
-			text.Draw(target, cmd.text.str, fontFace, op)
-		case commandIcon:
-			img := iconImage(cmd.icon.icon)
-			if img == nil {
+	b := screen.Bounds()
+	x0, y0 := c.screenToUI(b.Min.X, b.Min.Y)
+	x1, y1 := c.screenToUI(b.Max.X, b.Max.Y)
+	c.screenBounds = image.Rect(x0, y0, x1, y1)
+
+	c.renderer.Scale = c.uiScale()
+	if !c.transformed {
+		c.drawWindows(screen, image.Point{})
+		return
+	}
+
+	// render the UI in the UI coordinates and then draw it with the transform
+	bounds := c.commandBounds()
+	size := scalePoint(bounds.Size(), c.renderer.Scale)
+	if size.X <= 0 || size.Y <= 0 {
+		return
+	}
+	if c.offscreen != nil {
+		if s := c.offscreen.Bounds().Size(); s.X < size.X || s.Y < size.Y {
+			c.offscreen.Deallocate()
+			c.offscreen = nil
+		}
+	}
+	if c.offscreen == nil {
+		c.offscreen = ebiten.NewImage(size.X, size.Y)
+	}
+	c.offscreen.Clear()
+	c.drawWindows(c.offscreen, bounds.Min)
+
+	op := &ebiten.DrawImageOptions{}
+	pos := scalePoint(bounds.Min, c.renderer.Scale)
+	op.GeoM.Translate(float64(pos.X), float64(pos.Y))
+	op.GeoM.Concat(c.transform)
+	screen.DrawImage(c.offscreen.SubImage(image.Rectangle{Max: size}).(*ebiten.Image), op)
+}
+
+// commandBounds returns the bounds of all the commands in the UI coordinates,
+// i.e. the root containers with their borders and shadows, and the overlay such as the tooltip.
+func (c *Context) commandBounds() image.Rectangle {
+	var bounds image.Rectangle
+	for _, cnt := range c.rootList {
+		bounds = bounds.Union(c.windowBounds(cnt))
+	}
+	for it := c.overlayCommands(); it.Next(); {
+		cmd := it.Command()
+		switch cmd.Type() {
+		case CommandRect, CommandIcon, CommandRoundedRect, CommandOutline:
+			bounds = bounds.Union(cmd.Rect())
+		case CommandShadow:
+			bounds = bounds.Union(cmd.Rect().Inset(-cmd.Width()))
+		}
+	}
+	return bounds
+}
+
+// EbitenRenderer is a Renderer that draws the commands onto an *ebiten.Image with Ebitengine.
+//
+// Consecutive rectangles and icons sharing a clipping region are batched into one DrawTriangles call.
+// Rounded rectangles, outlines and shadows are drawn with anti-aliased vector paths.
+//
+// DebugUI.Draw uses an EbitenRenderer.
+type EbitenRenderer struct {
+	// Target is the image to draw onto.
+	Target *ebiten.Image
+
+	// Scale is the scale factor from the UI coordinates to the pixels of Target.
+	// Text and icons are scaled with the nearest filter to keep them sharp.
+	// Custom drawing functions draw in the UI coordinates onto an offscreen image, which is scaled.
+	//
+	// If Scale is 0, 1 is used.
+	Scale float64
+
+	// offset is subtracted from all the positions. This is used to draw a window into its cache.
+	offset image.Point
+
+	batch  batch
+	textOp text.DrawOptions
+
+	shapeVertices []ebiten.Vertex
+	shapeIndices  []uint16
+
+	// drawImage is the offscreen image for a custom drawing function drawn with a color scale.
+	drawImage *ebiten.Image
+}
+
+// Render implements Renderer.
+func (r *EbitenRenderer) Render(commands *CommandIterator) {
+	_, iconRects := atlas()
+	scale := r.Scale
+	if scale <= 0 {
+		scale = 1
+	}
+	target := r.Target
+	clip := unclippedRect
+	for commands.Next() {
+		cmd := commands.Command()
+		switch cmd.Type() {
+		case CommandRect:
+			if r.batch.full() {
+				r.batch.flush(target)
+			}
+			r.batch.appendQuad(scaleRect(cmd.Rect().Sub(r.offset), scale), atlasWhite, cmd.Color())
+		case CommandText:
+			r.batch.flush(target)
+			op := &r.textOp
+			pos := scalePoint(cmd.Pos().Sub(r.offset), scale)
+			op.GeoM.Reset()
+			op.GeoM.Scale(scale, scale)
+			op.GeoM.Translate(float64(pos.X), float64(pos.Y))
+			clr := cmd.Color()
+			op.ColorScale.Reset()
+			op.ColorScale.Scale(float32(clr.R)/0xff, float32(clr.G)/0xff, float32(clr.B)/0xff, float32(clr.A)/0xff)
+			text.Draw(target, cmd.Text(), cmd.Face(), op)
+		case CommandIcon:
+			src, ok := iconRects[cmd.Icon()]
+			if !ok {
 				continue
 			}
-			op := &ebiten.DrawImageOptions{}
-			x := cmd.icon.rect.Min.X + (cmd.icon.rect.Dx()-img.Bounds().Dx())/2
-			y := cmd.icon.rect.Min.Y + (cmd.icon.rect.Dy()-img.Bounds().Dy())/2
-			op.GeoM.Translate(float64(x), float64(y))
-			op.ColorScale.ScaleWithColor(cmd.icon.color)
-			target.DrawImage(img, op)
-		case commandDraw:
-			cmd.draw.f(target)
-		case commandClip:
-			target = screen.SubImage(cmd.clip.rect).(*ebiten.Image)
+			if r.batch.full() {
+				r.batch.flush(target)
+			}
+			rect := scaleRect(cmd.Rect().Sub(r.offset), scale)
+			size := scalePoint(src.Size(), scale)
+			x := rect.Min.X + (rect.Dx()-size.X)/2
+			y := rect.Min.Y + (rect.Dy()-size.Y)/2
+			r.batch.appendQuad(image.Rect(x, y, x+size.X, y+size.Y), src, cmd.Color())
+		case CommandRoundedRect, CommandOutline, CommandShadow:
+			r.batch.flush(target)
+			r.drawShape(target, cmd, scale)
+		case CommandDraw:
+			r.batch.flush(target)
+			r.drawFunc(target, cmd, clip, scale)
+		case CommandClip:
+			r.batch.flush(target)
+			clip = cmd.Rect()
+			target = r.Target.SubImage(scaleRect(cmd.Rect().Sub(r.offset), scale)).(*ebiten.Image)
 		}
 	}
+	r.batch.flush(target)
+}
+
+// drawFunc calls the custom drawing function of the CommandDraw command clipped by clip.
+// If the UI is scaled or the command has a color scale, e.g. the window is translucent,
+// the function draws in the UI coordinates onto an offscreen image, and it is drawn with the scale and the color scale.
+func (r *EbitenRenderer) drawFunc(target *ebiten.Image, cmd Command, clip image.Rectangle, scale float64) {
+	clr := cmd.Color()
+	if clr.A == 0 {
+		return
+	}
+	if clr == (color.RGBA{0xff, 0xff, 0xff, 0xff}) && scale == 1 && r.offset == (image.Point{}) {
+		cmd.DrawFunc()(target)
+		return
+	}
+
+	// the bounds of the target in the UI coordinates.
+	tb := r.Target.Bounds()
+	bounds := image.Rect(
+		int(math.Floor(float64(tb.Min.X)/scale)),
+		int(math.Floor(float64(tb.Min.Y)/scale)),
+		int(math.Ceil(float64(tb.Max.X)/scale)),
+		int(math.Ceil(float64(tb.Max.Y)/scale)),
+	).Add(r.offset).Intersect(clip).Intersect(unclippedRect)
+	if bounds.Empty() {
+		return
+	}
+	if r.drawImage != nil {
+		if s := r.drawImage.Bounds().Size(); s.X < bounds.Max.X || s.Y < bounds.Max.Y {
+			r.drawImage.Deallocate()
+			r.drawImage = nil
+		}
+	}
+	if r.drawImage == nil {
+		r.drawImage = ebiten.NewImage(bounds.Max.X, bounds.Max.Y)
+	}
+
+	img := r.drawImage.SubImage(bounds).(*ebiten.Image)
+	img.Clear()
+	cmd.DrawFunc()(img)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(bounds.Min.X-r.offset.X), float64(bounds.Min.Y-r.offset.Y))
+	op.GeoM.Scale(scale, scale)
+	op.ColorScale.Scale(float32(clr.R)/0xff, float32(clr.G)/0xff, float32(clr.B)/0xff, float32(clr.A)/0xff)
+	target.DrawImage(img, op)
 }
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestDrawFuncUICoordinates(t *testing.T) {
+	for _, scale := range []float64{1, 1.5, 2} {
+		d := newTestUI(newTestInput())
+		var clip image.Rectangle
+		var bounds []image.Rectangle
+		d.Update(func(ctx *Context) {
+			ctx.Window("Window", image.Rect(10, 20, 110, 120), func(res Response, layout Layout) {
+				ctx.SetLayoutRow([]int{-1}, 40)
+				ctx.Control(0, 0, func(r image.Rectangle) Response {
+					clip = ctx.clipRect()
+					ctx.Draw(func(screen *ebiten.Image) {
+						bounds = append(bounds, screen.Bounds())
+					})
+					return 0
+				})
+			})
+		})
+
+		target := ebiten.NewImage(int(200*scale), int(200*scale))
+		d.Render(&EbitenRenderer{Target: target, Scale: scale})
+		// the drawing function draws in the UI coordinates regardless of the scale.
+		if len(bounds) != 1 || bounds[0] != clip {
+			t.Errorf("scale %v: the bounds of the image for the drawing function: got %v, want [%v]", scale, bounds, clip)
+		}
+	}
+}
+
+func TestDrawTransformBounds(t *testing.T) {
+	input := newTestInput()
+	d := newTestUI(input)
+	input.cursor = image.Pt(150, 160)
+	window := image.Rect(-50, -30, 100, 100)
+	d.Update(func(ctx *Context) {
+		ctx.Window("Window", window, func(res Response, layout Layout) {})
+		ctx.addTooltip("Tooltip", color.RGBA{})
+	})
+
+	// the bounds include the window at the negative position and the tooltip outside the window.
+	bounds := d.ctx.commandBounds()
+	if got, want := bounds.Min, d.ctx.windowBounds(d.ctx.rootList[0]).Min; got != want {
+		t.Errorf("bounds.Min: got %v, want %v", got, want)
+	}
+	tooltip := image.Pt(150+tooltipOffset, 160+tooltipOffset)
+	if !tooltip.In(bounds) {
+		t.Errorf("bounds %v doesn't include the tooltip at %v", bounds, tooltip)
+	}
+
+	var geoM ebiten.GeoM
+	geoM.Translate(10, 20)
+	d.SetTransform(geoM)
+	d.Draw(ebiten.NewImage(320, 240))
+	if got, want := d.ctx.offscreen.Bounds().Size(), bounds.Size(); got != want {
+		t.Errorf("the size of the offscreen: got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"encoding/binary"
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// windowCache is the cached rendering of a root container.
+type windowCache struct {
+	image *ebiten.Image
+	hash  uint64
+	scale float64
+	valid bool
+	used  bool
+
+	// renders is the number of times the commands are rendered into the image.
+	renders int
+}
+
+// hashRootCommands hashes the commands of the root container relative to origin,
+// so that moving a window doesn't change the hash.
+// hashRootCommands returns false if the commands cannot be cached, e.g. they have custom drawing functions.
+func (c *Context) hashRootCommands(cnt *container, origin image.Point) (uint64, bool) {
+	h := &c.commandHash
+	h.Reset()
+	buf := c.commandHashBuf[:0]
+	appendRect := func(b []byte, r image.Rectangle) []byte {
+		r = r.Sub(origin)
+		b = binary.AppendVarint(b, int64(r.Min.X))
+		b = binary.AppendVarint(b, int64(r.Min.Y))
+		b = binary.AppendVarint(b, int64(r.Max.X))
+		return binary.AppendVarint(b, int64(r.Max.Y))
+	}
+
+	it := c.rootCommands(cnt)
+	for it.Next() {
+		cmd := it.Command()
+		buf = binary.AppendUvarint(buf[:0], uint64(cmd.Type()))
+		switch cmd.Type() {
+		case CommandClip, CommandRect, CommandIcon:
+			buf = appendRect(buf, cmd.Rect())
+		case CommandRoundedRect, CommandOutline, CommandShadow:
+			buf = appendRect(buf, cmd.Rect())
+			buf = binary.AppendUvarint(buf, uint64(cmd.Radius()))
+			buf = binary.AppendUvarint(buf, uint64(cmd.Width()))
+		case CommandText:
+			p := cmd.Pos().Sub(origin)
+			buf = binary.AppendVarint(buf, int64(p.X))
+			buf = binary.AppendVarint(buf, int64(p.Y))
+			buf = binary.AppendUvarint(buf, uint64(len(cmd.Text())))
+			buf = append(buf, cmd.Text()...)
+			buf = binary.AppendUvarint(buf, uint64(cmd.cmd.font))
+		case CommandDraw:
+			c.commandHashBuf = buf
+			return 0, false
+		}
+		if cmd.Type() == CommandIcon {
+			buf = binary.AppendUvarint(buf, uint64(cmd.Icon()))
+		}
+		clr := cmd.Color()
+		buf = append(buf, clr.R, clr.G, clr.B, clr.A)
+		_, _ = h.Write(buf)
+	}
+	c.commandHashBuf = buf
+	return h.Sum64(), true
+}
+
+// drawWindows draws the root containers and the overlay onto target.
+// origin is the point in the UI coordinates drawn at the origin of target.
+// A window whose commands are not changed since the last frame is drawn from its cached image.
+func (c *Context) drawWindows(target *ebiten.Image, origin image.Point) {
+	for _, wc := range c.windowCaches {
+		wc.used = false
+	}
+
+	for _, cnt := range c.rootList {
+		bounds := c.windowBounds(cnt)
+		hash, ok := c.hashRootCommands(cnt, bounds.Min)
+		if !ok || scaleRect(bounds, c.renderer.Scale).Empty() {
+			c.renderer.Target = target
+			c.renderer.offset = origin
+			c.renderer.Render(c.rootCommands(cnt))
+			continue
+		}
+
+		if c.windowCaches == nil {
+			c.windowCaches = map[*container]*windowCache{}
+		}
+		wc, ok := c.windowCaches[cnt]
+		if !ok {
+			wc = &windowCache{}
+			c.windowCaches[cnt] = wc
+		}
+		wc.used = true
+
+		// the commands in the cache are relative to the window, so the size is scaled from the origin.
+		size := scalePoint(bounds.Size(), c.renderer.Scale)
+		if wc.image != nil && wc.image.Bounds().Size() != size {
+			wc.image.Deallocate()
+			wc.image = nil
+		}
+		if wc.image == nil {
+			wc.image = ebiten.NewImage(size.X, size.Y)
+			wc.valid = false
+		}
+		if !wc.valid || wc.hash != hash || wc.scale != c.renderer.Scale {
+			wc.image.Clear()
+			c.renderer.Target = wc.image
+			c.renderer.offset = bounds.Min
+			c.renderer.Render(c.rootCommands(cnt))
+			wc.hash = hash
+			wc.scale = c.renderer.Scale
+			wc.valid = true
+			wc.renders++
+		}
+
+		op := &ebiten.DrawImageOptions{}
+		pos := scalePoint(bounds.Min.Sub(origin), c.renderer.Scale)
+		op.GeoM.Translate(float64(pos.X), float64(pos.Y))
+		target.DrawImage(wc.image, op)
+	}
+
+	// dispose the caches of the windows that are not drawn anymore
+	for cnt, wc := range c.windowCaches {
+		if wc.used {
+			continue
+		}
+		if wc.image != nil {
+			wc.image.Deallocate()
+		}
+		delete(c.windowCaches, cnt)
+	}
+
+	c.renderer.Target = target
+	c.renderer.offset = origin
+	c.renderer.Render(c.overlayCommands())
+	c.renderer.offset = image.Point{}
+}
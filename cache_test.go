@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/bitmapfont/v3"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+func TestHashRootCommands(t *testing.T) {
+	d := newTestUI(newTestInput())
+	label := "foo"
+	draw := false
+	hash := func() (uint64, bool) {
+		d.Update(func(ctx *Context) {
+			ctx.Window("Window", image.Rect(10, 10, 210, 110), func(res Response, layout Layout) {
+				ctx.Text(label)
+				if draw {
+					ctx.Draw(func(screen *ebiten.Image) {})
+				}
+			})
+		})
+		c := d.ctx
+		cnt := c.rootList[0]
+		return c.hashRootCommands(cnt, c.windowBounds(cnt).Min)
+	}
+	move := func(rect image.Rectangle) {
+		d.ctx.rootList[0].layout.Rect = rect
+	}
+
+	base, ok := hash()
+	if !ok {
+		t.Fatal("hashRootCommands: got false, want true")
+	}
+	if got, ok := hash(); !ok || got != base {
+		t.Errorf("same commands: got (%x, %t), want (%x, true)", got, ok, base)
+	}
+
+	move(image.Rect(50, 40, 250, 140))
+	if got, ok := hash(); !ok || got != base {
+		t.Errorf("moved window: got (%x, %t), want (%x, true)", got, ok, base)
+	}
+
+	move(image.Rect(50, 40, 350, 140))
+	if got, ok := hash(); !ok || got == base {
+		t.Errorf("resized window: got (%x, %t), want a hash other than %x", got, ok, base)
+	}
+
+	move(image.Rect(10, 10, 210, 110))
+	label = "bar"
+	if got, ok := hash(); !ok || got == base {
+		t.Errorf("changed text: got (%x, %t), want a hash other than %x", got, ok, base)
+	}
+
+	label = "foo"
+	draw = true
+	if _, ok := hash(); ok {
+		t.Error("window with a drawing function: got true, want false")
+	}
+}
+
+func TestWindowCache(t *testing.T) {
+	d := newTestUI(newTestInput())
+	screen := ebiten.NewImage(320, 240)
+	label := "foo"
+	draw := false
+	f := func(ctx *Context) {
+		ctx.Window("Window", image.Rect(10, 10, 210, 110), func(res Response, layout Layout) {
+			ctx.Text(label)
+			if draw {
+				ctx.Draw(func(screen *ebiten.Image) {})
+			}
+		})
+	}
+	frame := func() {
+		d.Update(f)
+		d.Draw(screen)
+	}
+	renders := func() int {
+		wc, ok := d.ctx.windowCaches[d.ctx.rootList[0]]
+		if !ok {
+			return -1
+		}
+		return wc.renders
+	}
+
+	frame()
+	if got, want := renders(), 1; got != want {
+		t.Fatalf("first frame: got %d renders, want %d", got, want)
+	}
+
+	testCases := []struct {
+		name    string
+		change  func()
+		wantAdd int
+	}{
+		{
+			name:    "unchanged",
+			change:  func() {},
+			wantAdd: 0,
+		},
+		{
+			name: "moved",
+			change: func() {
+				cnt := d.ctx.rootList[0]
+				cnt.layout.Rect = cnt.layout.Rect.Add(image.Pt(30, 20))
+			},
+			wantAdd: 0,
+		},
+		{
+			name:    "content",
+			change:  func() { label = "bar" },
+			wantAdd: 1,
+		},
+		{
+			name:    "font face",
+			change:  func() { d.SetFontFace(FontRegular, text.NewGoXFace(bitmapfont.Face)) },
+			wantAdd: 1,
+		},
+		{
+			name:    "scale",
+			change:  func() { d.SetScale(2) },
+			wantAdd: 1,
+		},
+	}
+	for _, tc := range testCases {
+		before := renders()
+		tc.change()
+		frame()
+		if got, want := renders(), before+tc.wantAdd; got != want {
+			t.Errorf("%s: got %d renders, want %d", tc.name, got, want)
+		}
+	}
+
+	// a window with a drawing function is not cached, and its cache is disposed.
+	draw = true
+	frame()
+	if got := renders(); got != -1 {
+		t.Errorf("drawing function: got a cache with %d renders, want no cache", got)
+	}
+}
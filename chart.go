@@ -0,0 +1,282 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// barHoverAlpha is the alpha of the highlight of the hovered bar relative to the text color.
+const barHoverAlpha = 0.15
+
+// BarSeries is a series of values drawn by BarChart.
+type BarSeries struct {
+	// Label is the name of the series shown in the legend and the tooltip.
+	Label string
+
+	// Values is the values of the bars.
+	// Negative and NaN values are drawn and shown in the tooltip as 0.
+	Values []float64
+
+	// Color is the color of the bars.
+	// If Color is zero, a color of the palette is used.
+	Color color.RGBA
+}
+
+// BarChartOptions represents options for BarChart and Histogram.
+type BarChartOptions struct {
+	// Horizontal makes the bars grow from left to right instead of from bottom to top.
+	Horizontal bool
+
+	// Max is the maximum value of the axis.
+	//
+	// If Max is 0, the maximum is computed from the values every frame.
+	Max float64
+
+	// Height is the height of the chart.
+	//
+	// If Height is 0, the chart is as tall as 5 rows, or as tall as all the bars for a horizontal chart.
+	Height int
+
+	// Color is the color of the bars of the values.
+	// If Color is zero, a color of the palette is used.
+	Color color.RGBA
+
+	// Series is the other series stacked on the values.
+	// The legend is shown when Series is not empty.
+	Series []BarSeries
+}
+
+// barValue returns the length of a bar of the value.
+func barValue(v float64) float64 {
+	if math.IsNaN(v) || v < 0 {
+		return 0
+	}
+	return v
+}
+
+// barSlot returns the rectangle of the i-th of n bars that divide rect along the direction.
+func barSlot(rect image.Rectangle, n, i int, horizontal bool) image.Rectangle {
+	if horizontal {
+		return image.Rect(rect.Min.X, rect.Min.Y+i*rect.Dy()/n, rect.Max.X, rect.Min.Y+(i+1)*rect.Dy()/n)
+	}
+	return image.Rect(rect.Min.X+i*rect.Dx()/n, rect.Min.Y, rect.Min.X+(i+1)*rect.Dx()/n, rect.Max.Y)
+}
+
+// drawBarChart draws the bars in area and returns the index of the bar at the mouse cursor, or -1.
+func (c *Context) drawBarChart(area image.Rectangle, labels []string, series []BarSeries, n int, hi float64, horizontal bool) int {
+	lh := c.lineHeight()
+	gridColor := scaleColor(c.style.colors[ColorText], plotGridAlpha)
+	var buf [plotMaxGridLines]float64
+	grid, step := appendGridValues(buf[:0], 0, hi)
+
+	// axis is the area of the bars, and pos returns the position of the value along the axis.
+	// pos(0) is the edge of the axis, so a bar of 0 is not drawn in either direction.
+	// The values of the grid are shown in the last line.
+	var axis, slotsRect image.Rectangle
+	var pos func(v float64) int
+	if horizontal {
+		var labelWidth int
+		for _, l := range labels {
+			labelWidth = max(labelWidth, c.textWidth(l))
+		}
+		if labelWidth > 0 {
+			labelWidth += c.style.padding * 2
+		}
+		axis = image.Rect(area.Min.X+labelWidth, area.Min.Y, area.Max.X, area.Max.Y-lh)
+		slotsRect = image.Rect(area.Min.X, axis.Min.Y, area.Max.X, axis.Max.Y)
+		pos = func(v float64) int {
+			return axis.Min.X + int(clampF(v/hi, 0, 1)*float64(axis.Dx())+0.5)
+		}
+		for _, v := range grid {
+			x := min(pos(v), axis.Max.X-1)
+			c.drawRect(image.Rect(x, axis.Min.Y, x+1, axis.Max.Y), gridColor)
+			c.drawText(formatStepValue(v, step), image.Pt(x+c.style.padding, axis.Max.Y), gridColor)
+		}
+	} else {
+		axis = image.Rect(area.Min.X, area.Min.Y, area.Max.X, area.Max.Y-lh)
+		slotsRect = axis
+		pos = func(v float64) int {
+			return axis.Max.Y - int(clampF(v/hi, 0, 1)*float64(axis.Dy())+0.5)
+		}
+		for _, v := range grid {
+			y := min(pos(v), axis.Max.Y-1)
+			c.drawRect(image.Rect(axis.Min.X, y, axis.Max.X, y+1), gridColor)
+			str := formatStepValue(v, step)
+			c.drawText(str, image.Pt(axis.Max.X-c.textWidth(str)-c.style.padding, max(y-lh, axis.Min.Y)), gridColor)
+		}
+	}
+
+	hovered := -1
+	for i := 0; i < n; i++ {
+		slot := barSlot(slotsRect, n, i, horizontal)
+		if c.mouseOver(slot) {
+			hovered = i
+			c.drawRect(slot, scaleColor(c.style.colors[ColorText], barHoverAlpha))
+		}
+
+		// the bars have gaps between them.
+		var bar image.Rectangle
+		if horizontal {
+			gap := max(slot.Dy()/8, 1)
+			bar = image.Rect(axis.Min.X, slot.Min.Y+gap, axis.Min.X, slot.Max.Y-gap)
+		} else {
+			gap := max(slot.Dx()/8, 1)
+			bar = image.Rect(slot.Min.X+gap, axis.Max.Y, slot.Max.X-gap, axis.Max.Y)
+		}
+		var total float64
+		for j, s := range series {
+			if i >= len(s.Values) {
+				continue
+			}
+			total += barValue(s.Values[i])
+			if horizontal {
+				bar.Min.X = bar.Max.X
+				bar.Max.X = pos(total)
+			} else {
+				bar.Max.Y = bar.Min.Y
+				bar.Min.Y = pos(total)
+			}
+			c.drawRect(bar, seriesColor(j, s.Color))
+		}
+
+		if i < len(labels) {
+			if horizontal {
+				c.drawControlText(labels[i], image.Rect(area.Min.X, slot.Min.Y, axis.Min.X, slot.Max.Y), ColorText, 0)
+			} else {
+				c.drawControlText(labels[i], image.Rect(slot.Min.X, axis.Max.Y, slot.Max.X, area.Max.Y), ColorText, optionAlignCenter)
+			}
+		}
+	}
+	return hovered
+}
+
+func (c *Context) barChart(label string, labels []string, values []float64, opts *BarChartOptions) {
+	if opts == nil {
+		opts = &BarChartOptions{}
+	}
+	c.barSeries = append(c.barSeries[:0], BarSeries{Label: label, Values: values, Color: opts.Color})
+	c.barSeries = append(c.barSeries, opts.Series...)
+	series := c.barSeries
+	n := len(labels)
+	for _, s := range series {
+		n = max(n, len(s.Values))
+	}
+	hi := opts.Max
+	if hi <= 0 {
+		for i := 0; i < n; i++ {
+			var total float64
+			for _, s := range series {
+				if i < len(s.Values) {
+					total += barValue(s.Values[i])
+				}
+			}
+			hi = max(hi, total)
+		}
+	}
+	if hi <= 0 || math.IsInf(hi, 0) {
+		hi = 1
+	}
+	lh := c.lineHeight()
+	h := opts.Height
+	if h == 0 {
+		if opts.Horizontal {
+			// a line for the title, a line for each bar and a line for the values of the grid.
+			h = (n+2)*(lh+c.style.spacing) + c.style.padding
+		} else {
+			h = c.defaultRowHeight() * plotDefaultRows
+		}
+	}
+
+	c.LayoutColumn(func() {
+		c.SetLayoutRow([]int{-1}, h)
+		c.Control(0, 0, func(r image.Rectangle) Response {
+			c.drawFrame(r, ColorBase)
+			area := r.Inset(c.style.padding / 2)
+			c.pushClipRect(area)
+			defer c.popClipRect()
+
+			if len(label) > 0 {
+				c.drawText(label, area.Min.Add(image.Pt(c.style.padding, 0)), c.style.colors[ColorText])
+			}
+			area.Min.Y += lh
+			if n == 0 || area.Dx() <= 0 || area.Dy() <= 0 {
+				return 0
+			}
+
+			// show the values of the bar at the mouse cursor
+			i := c.drawBarChart(area, labels, series, n, hi, opts.Horizontal)
+			if i < 0 {
+				return 0
+			}
+			if i < len(labels) {
+				c.addTooltip(labels[i], color.RGBA{})
+			}
+			var total float64
+			for j, s := range series {
+				if i >= len(s.Values) {
+					continue
+				}
+				v := barValue(s.Values[i])
+				total += v
+				c.addTooltipValue(s.Label, v, seriesColor(j, s.Color))
+			}
+			if len(series) > 1 {
+				c.addTooltipValue("Total", total, color.RGBA{})
+			}
+			return 0
+		})
+
+		if len(opts.Series) > 0 {
+			legend(c, series)
+		}
+	})
+}
+
+// histogramBuckets counts the samples of each series in the buckets that divide the range of all the samples evenly.
+// The label of a bucket is its lower bound.
+// NaN and infinite samples are not counted. If buckets is less than 1, 1 is used.
+func histogramBuckets(series [][]float64, buckets int) (labels []string, counts [][]float64) {
+	buckets = max(buckets, 1)
+	lo, hi := plotRange(series...)
+	width := (hi - lo) / float64(buckets)
+	labels = make([]string, buckets)
+	for i := range labels {
+		labels[i] = formatStepValue(lo+float64(i)*width, niceStep(width, 1))
+	}
+	counts = make([][]float64, len(series))
+	for i, samples := range series {
+		counts[i] = make([]float64, buckets)
+		for _, v := range samples {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				continue
+			}
+			b := clamp(int((v-lo)/width), 0, buckets-1)
+			counts[i][b]++
+		}
+	}
+	return labels, counts
+}
+
+func (c *Context) histogram(label string, samples []float64, buckets int, opts *BarChartOptions) {
+	if opts == nil {
+		opts = &BarChartOptions{}
+	}
+	series := make([][]float64, 0, len(opts.Series)+1)
+	series = append(series, samples)
+	for _, s := range opts.Series {
+		series = append(series, s.Values)
+	}
+	labels, counts := histogramBuckets(series, buckets)
+
+	o := *opts
+	o.Series = make([]BarSeries, len(opts.Series))
+	for i, s := range opts.Series {
+		o.Series[i] = s
+		o.Series[i].Values = counts[i+1]
+	}
+	c.barChart(label, labels, counts[0], &o)
+}
@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"slices"
+	"testing"
+)
+
+// barChartFrame returns a frame with a bar chart of the values, and the chart's rectangle.
+func barChartFrame(values []float64, horizontal bool, rect *image.Rectangle) func(ctx *Context) {
+	return func(ctx *Context) {
+		ctx.Window("Window", image.Rect(0, 0, 300, 300), func(res Response, layout Layout) {
+			ctx.BarChart("", []string{"a", "b", "c"}, values, &BarChartOptions{
+				Horizontal: horizontal,
+				Color:      color.RGBA{0xff, 0, 0, 0xff},
+			})
+			*rect = ctx.lastRect
+		})
+	}
+}
+
+func TestBarChartZeroBars(t *testing.T) {
+	for _, horizontal := range []bool{false, true} {
+		d := newTestUI(newTestInput())
+		var rect image.Rectangle
+		d.Update(barChartFrame([]float64{0, 1, -1}, horizontal, &rect))
+		var bars int
+		for it := d.Commands(); it.Next(); {
+			cmd := it.Command()
+			if cmd.Type() == CommandRect && cmd.Color() == (color.RGBA{0xff, 0, 0, 0xff}) {
+				bars++
+			}
+		}
+		// only the bar of 1 is drawn.
+		if bars != 1 {
+			t.Errorf("horizontal: %v: got %d bars, want 1", horizontal, bars)
+		}
+	}
+}
+
+func TestBarChartTooltipClamped(t *testing.T) {
+	input := newTestInput()
+	d := newTestUI(input)
+	var rect image.Rectangle
+	f := barChartFrame([]float64{-3, 1, 2}, false, &rect)
+	d.Update(f)
+
+	// hover the first bar.
+	input.cursor = image.Pt(rect.Min.X+rect.Dx()/6, rect.Min.Y+rect.Dy()/2)
+	d.Update(f)
+	var texts []string
+	for it := d.Commands(); it.Next(); {
+		if cmd := it.Command(); cmd.Type() == CommandText {
+			texts = append(texts, cmd.Text())
+		}
+	}
+	if !slices.Contains(texts, ": 0") {
+		t.Errorf("the tooltip doesn't show the clamped value: %q", texts)
+	}
+	if slices.Contains(texts, ": -3") {
+		t.Errorf("the tooltip shows the raw value: %q", texts)
+	}
+}
+
+func TestHistogramBuckets(t *testing.T) {
+	testCases := []struct {
+		name       string
+		series     [][]float64
+		buckets    int
+		wantLabels []string
+		wantCounts [][]float64
+	}{
+		{
+			name:       "even",
+			series:     [][]float64{{0, 1, 2, 3, 4, 5, 6, 7, 8, 10}},
+			buckets:    5,
+			wantLabels: []string{"0", "2", "4", "6", "8"},
+			wantCounts: [][]float64{{2, 2, 2, 2, 2}},
+		},
+		{
+			name:       "series",
+			series:     [][]float64{{0, 1}, {3, 4}},
+			buckets:    2,
+			wantLabels: []string{"0", "2"},
+			wantCounts: [][]float64{{2, 0}, {0, 2}},
+		},
+		{
+			name:       "all equal",
+			series:     [][]float64{{5, 5, 5}},
+			buckets:    4,
+			wantLabels: []string{"4.5", "4.8", "5.0", "5.2"},
+			wantCounts: [][]float64{{0, 0, 3, 0}},
+		},
+		{
+			name:       "empty",
+			series:     [][]float64{{}},
+			buckets:    2,
+			wantLabels: []string{"0.0", "0.5"},
+			wantCounts: [][]float64{{0, 0}},
+		},
+		{
+			name:       "NaN and Inf",
+			series:     [][]float64{{math.NaN(), 0, math.Inf(1), 4, math.Inf(-1)}},
+			buckets:    2,
+			wantLabels: []string{"0", "2"},
+			wantCounts: [][]float64{{1, 1}},
+		},
+		{
+			name:       "zero buckets",
+			series:     [][]float64{{0, 1, 2}},
+			buckets:    0,
+			wantLabels: []string{"0"},
+			wantCounts: [][]float64{{3}},
+		},
+		{
+			name:       "negative buckets",
+			series:     [][]float64{{0, 1, 2}},
+			buckets:    -1,
+			wantLabels: []string{"0"},
+			wantCounts: [][]float64{{3}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			labels, counts := histogramBuckets(tc.series, tc.buckets)
+			if !slices.Equal(labels, tc.wantLabels) {
+				t.Errorf("labels: got %q, want %q", labels, tc.wantLabels)
+			}
+			if !slices.EqualFunc(counts, tc.wantCounts, slices.Equal[[]float64]) {
+				t.Errorf("counts: got %v, want %v", counts, tc.wantCounts)
+			}
+		})
+	}
+}
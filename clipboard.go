@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"fmt"
+	"os"
+)
+
+// Clipboard is a clipboard that TextBox copies text to and pastes text from.
+//
+// Implement Clipboard to connect DebugUI to the clipboard of the OS.
+type Clipboard interface {
+	// ReadText returns the text in the clipboard.
+	ReadText() (string, error)
+
+	// WriteText writes the text to the clipboard.
+	WriteText(text string) error
+}
+
+// MemoryClipboard is a Clipboard that holds the text in memory.
+//
+// The zero value for MemoryClipboard is an empty clipboard ready to use.
+type MemoryClipboard struct {
+	text string
+}
+
+// ReadText implements Clipboard.
+func (m *MemoryClipboard) ReadText() (string, error) {
+	return m.text, nil
+}
+
+// WriteText implements Clipboard.
+func (m *MemoryClipboard) WriteText(text string) error {
+	m.text = text
+	return nil
+}
+
+// isShortcutDown reports whether the modifier of the shortcuts, Control or Command, is held.
+func (c *Context) isShortcutDown() bool {
+	return (c.keyDown & (keyControl | keyMeta)) != 0
+}
+
+// handleClipboard handles the select-all, copy, cut and paste shortcuts for the text field.
+// handleClipboard returns true if the text is changed.
+func (c *Context) handleClipboard(f *textField) bool {
+	if !c.isShortcutDown() {
+		return false
+	}
+
+	text := f.Text()
+	start, end := f.Selection()
+
+	switch {
+	case (c.keyPressed & keyA) != 0:
+		f.SetSelection(0, len(text))
+	case (c.keyPressed & (keyC | keyX)) != 0:
+		if start == end {
+			return false
+		}
+		if err := c.clipboard.WriteText(text[start:end]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return false
+		}
+		if (c.keyPressed & keyX) != 0 {
+			f.SetTextAndSelection(text[:start]+text[end:], start, start)
+			return true
+		}
+	case (c.keyPressed & keyV) != 0:
+		str, err := c.clipboard.ReadText()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return false
+		}
+		if str == "" && start == end {
+			return false
+		}
+		f.SetTextAndSelection(text[:start]+str+text[end:], start+len(str), start+len(str))
+		return true
+	}
+	return false
+}
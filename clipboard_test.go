@@ -0,0 +1,268 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestMemoryClipboard(t *testing.T) {
+	testCases := []struct {
+		name   string
+		writes []string
+		want   string
+	}{
+		{
+			name: "zero value",
+			want: "",
+		},
+		{
+			name:   "write",
+			writes: []string{"foo"},
+			want:   "foo",
+		},
+		{
+			name:   "overwrite",
+			writes: []string{"foo", "bar"},
+			want:   "bar",
+		},
+		{
+			name:   "clear",
+			writes: []string{"foo", ""},
+			want:   "",
+		},
+		{
+			name:   "multibyte",
+			writes: []string{"あいう"},
+			want:   "あいう",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var m MemoryClipboard
+			for _, w := range tc.writes {
+				if err := m.WriteText(w); err != nil {
+					t.Fatalf("WriteText(%q) failed: %v", w, err)
+				}
+			}
+			got, err := m.ReadText()
+			if err != nil {
+				t.Fatalf("ReadText failed: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("ReadText() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHandleClipboard(t *testing.T) {
+	testCases := []struct {
+		name          string
+		text          string
+		start, end    int
+		clipboard     string
+		keyDown       int
+		keyPressed    int
+		wantChanged   bool
+		wantText      string
+		wantStart     int
+		wantEnd       int
+		wantClipboard string
+	}{
+		{
+			name:          "select all",
+			text:          "hello",
+			start:         2,
+			end:           2,
+			keyDown:       keyControl,
+			keyPressed:    keyA,
+			wantText:      "hello",
+			wantStart:     0,
+			wantEnd:       5,
+			wantClipboard: "",
+		},
+		{
+			name:          "copy",
+			text:          "hello",
+			start:         1,
+			end:           4,
+			clipboard:     "old",
+			keyDown:       keyControl,
+			keyPressed:    keyC,
+			wantText:      "hello",
+			wantStart:     1,
+			wantEnd:       4,
+			wantClipboard: "ell",
+		},
+		{
+			name:          "copy without selection",
+			text:          "hello",
+			start:         3,
+			end:           3,
+			clipboard:     "old",
+			keyDown:       keyControl,
+			keyPressed:    keyC,
+			wantText:      "hello",
+			wantStart:     3,
+			wantEnd:       3,
+			wantClipboard: "old",
+		},
+		{
+			name:          "cut",
+			text:          "hello",
+			start:         1,
+			end:           4,
+			keyDown:       keyMeta,
+			keyPressed:    keyX,
+			wantChanged:   true,
+			wantText:      "ho",
+			wantStart:     1,
+			wantEnd:       1,
+			wantClipboard: "ell",
+		},
+		{
+			name:          "paste",
+			text:          "hello",
+			start:         5,
+			end:           5,
+			clipboard:     " world",
+			keyDown:       keyControl,
+			keyPressed:    keyV,
+			wantChanged:   true,
+			wantText:      "hello world",
+			wantStart:     11,
+			wantEnd:       11,
+			wantClipboard: " world",
+		},
+		{
+			name:          "paste over selection",
+			text:          "hello",
+			start:         1,
+			end:           4,
+			clipboard:     "ipp",
+			keyDown:       keyControl,
+			keyPressed:    keyV,
+			wantChanged:   true,
+			wantText:      "hippo",
+			wantStart:     4,
+			wantEnd:       4,
+			wantClipboard: "ipp",
+		},
+		{
+			name:          "paste empty",
+			text:          "hello",
+			start:         2,
+			end:           2,
+			keyDown:       keyControl,
+			keyPressed:    keyV,
+			wantText:      "hello",
+			wantStart:     2,
+			wantEnd:       2,
+			wantClipboard: "",
+		},
+		{
+			name:          "paste empty over selection",
+			text:          "hello",
+			start:         1,
+			end:           4,
+			keyDown:       keyControl,
+			keyPressed:    keyV,
+			wantChanged:   true,
+			wantText:      "ho",
+			wantStart:     1,
+			wantEnd:       1,
+			wantClipboard: "",
+		},
+		{
+			name:          "multibyte",
+			text:          "あいう",
+			start:         3,
+			end:           6,
+			keyDown:       keyControl,
+			keyPressed:    keyX,
+			wantChanged:   true,
+			wantText:      "あう",
+			wantStart:     3,
+			wantEnd:       3,
+			wantClipboard: "い",
+		},
+		{
+			name:          "without modifier",
+			text:          "hello",
+			start:         1,
+			end:           4,
+			clipboard:     "old",
+			keyPressed:    keyC,
+			wantText:      "hello",
+			wantStart:     1,
+			wantEnd:       4,
+			wantClipboard: "old",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clipboard := &MemoryClipboard{text: tc.clipboard}
+			c := &Context{
+				clipboard:  clipboard,
+				keyDown:    tc.keyDown,
+				keyPressed: tc.keyPressed,
+			}
+			var f textField
+			f.SetTextAndSelection(tc.text, tc.start, tc.end)
+
+			if got := c.handleClipboard(&f); got != tc.wantChanged {
+				t.Errorf("handleClipboard() = %t, want %t", got, tc.wantChanged)
+			}
+			if got := f.Text(); got != tc.wantText {
+				t.Errorf("text: got %q, want %q", got, tc.wantText)
+			}
+			if start, end := f.Selection(); start != tc.wantStart || end != tc.wantEnd {
+				t.Errorf("selection: got (%d, %d), want (%d, %d)", start, end, tc.wantStart, tc.wantEnd)
+			}
+			if clipboard.text != tc.wantClipboard {
+				t.Errorf("clipboard: got %q, want %q", clipboard.text, tc.wantClipboard)
+			}
+		})
+	}
+}
+
+func TestTextBoxClipboard(t *testing.T) {
+	input := newTestInput()
+	clipboard := &MemoryClipboard{}
+	d := NewWithOptions(&Options{
+		InputSource:   input,
+		Clipboard:     clipboard,
+		NoDeviceScale: true,
+	})
+	buf := "hello"
+	var textBoxRect image.Rectangle
+	f := func(ctx *Context) {
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(res Response, layout Layout) {
+			ctx.SetLayoutRow([]int{-1}, 0)
+			ctx.TextBox(&buf)
+			textBoxRect = ctx.lastRect
+		})
+	}
+	d.Update(f)
+	click(d, input, textBoxRect.Min.Add(image.Pt(2, 2)), f)
+
+	input.keys[ebiten.KeyControl] = true
+	press(d, input, ebiten.KeyA, f)
+	press(d, input, ebiten.KeyX, f)
+	if buf != "" {
+		t.Errorf("text after cut: got %q, want %q", buf, "")
+	}
+	if clipboard.text != "hello" {
+		t.Errorf("clipboard after cut: got %q, want %q", clipboard.text, "hello")
+	}
+	press(d, input, ebiten.KeyV, f)
+	press(d, input, ebiten.KeyV, f)
+	if buf != "hellohello" {
+		t.Errorf("text after paste: got %q, want %q", buf, "hellohello")
+	}
+}
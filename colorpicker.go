@@ -0,0 +1,424 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+const (
+	// colorPickerSquareRows is the height of the saturation/value square of a color picker in rows.
+	colorPickerSquareRows = 6
+
+	// colorPickerStripWidth is the width of a strip of the gradients of a color picker.
+	colorPickerStripWidth = 2
+
+	// colorPickerKeyStep is how much an arrow key changes a component of a color picker.
+	colorPickerKeyStep = 0.01
+
+	// colorCheckerSize is the size of a cell of the checkerboard under translucent colors.
+	colorCheckerSize = 4
+)
+
+var (
+	colorCheckerLight = color.RGBA{0xcc, 0xcc, 0xcc, 0xff}
+	colorCheckerDark  = color.RGBA{0x88, 0x88, 0x88, 0xff}
+	colorMarkerInner  = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	colorMarkerOuter  = color.RGBA{0x00, 0x00, 0x00, 0xff}
+)
+
+// colorPickerState is the HSV state of a color picker.
+// The state is kept across frames so that the hue and the saturation are not lost
+// when the color becomes gray, black or transparent.
+type colorPickerState struct {
+	// clr is the color that the state represents.
+	clr color.RGBA
+
+	// h is the hue in degrees, and s, v and a are the saturation, the value and the alpha in [0, 1].
+	h, s, v, a float64
+
+	// hex is the buffer of the hex text entry.
+	hex string
+
+	// used reports whether the color picker is updated in this frame.
+	used bool
+}
+
+// hsvToRGB converts the color in HSV to RGB in [0, 1].
+func hsvToRGB(h, s, v float64) (r, g, b float64) {
+	h = math.Mod(h, 360) / 60
+	if h < 0 {
+		h += 6
+	}
+	i := math.Floor(h)
+	f := h - i
+	p := v * (1 - s)
+	q := v * (1 - s*f)
+	t := v * (1 - s*(1-f))
+	switch int(i) {
+	case 0:
+		return v, t, p
+	case 1:
+		return q, v, p
+	case 2:
+		return p, v, t
+	case 3:
+		return p, q, v
+	case 4:
+		return t, p, v
+	default:
+		return v, p, q
+	}
+}
+
+// hsvaToRGBA converts the color in HSV and the alpha to a premultiplied color.
+func hsvaToRGBA(h, s, v, a float64) color.RGBA {
+	r, g, b := hsvToRGB(h, s, v)
+	return color.RGBA{
+		R: uint8(r*a*0xff + 0.5),
+		G: uint8(g*a*0xff + 0.5),
+		B: uint8(b*a*0xff + 0.5),
+		A: uint8(a*0xff + 0.5),
+	}
+}
+
+// setColor sets the premultiplied color to the state.
+func (st *colorPickerState) setColor(clr color.RGBA) {
+	if clr.A == 0 {
+		// the other components are unknown, so they are kept.
+		st.a = 0
+	} else {
+		st.setNRGBA(color.NRGBAModel.Convert(clr).(color.NRGBA))
+	}
+	st.clr = clr
+}
+
+// setNRGBA sets the non-premultiplied color to the state.
+// The hue is kept for a gray color, and the saturation is kept for black.
+func (st *colorPickerState) setNRGBA(n color.NRGBA) {
+	r, g, b := float64(n.R)/0xff, float64(n.G)/0xff, float64(n.B)/0xff
+	mx := max(r, g, b)
+	mn := min(r, g, b)
+	st.v = mx
+	st.a = float64(n.A) / 0xff
+	if mx > 0 {
+		st.s = (mx - mn) / mx
+	}
+	if d := mx - mn; d > 0 {
+		var h float64
+		switch mx {
+		case r:
+			h = (g - b) / d
+		case g:
+			h = (b-r)/d + 2
+		default:
+			h = (r-g)/d + 4
+		}
+		h *= 60
+		if h < 0 {
+			h += 360
+		}
+		st.h = h
+	}
+	st.clr = st.rgba()
+}
+
+func (st *colorPickerState) rgba() color.RGBA {
+	return hsvaToRGBA(st.h, st.s, st.v, st.a)
+}
+
+// hexString returns the non-premultiplied color of the state in the form of #RRGGBBAA.
+func (st *colorPickerState) hexString() string {
+	r, g, b := hsvToRGB(st.h, st.s, st.v)
+	return fmt.Sprintf("#%02X%02X%02X%02X", uint8(r*0xff+0.5), uint8(g*0xff+0.5), uint8(b*0xff+0.5), uint8(st.a*0xff+0.5))
+}
+
+// apply stores the color of the state to clr, and reports whether clr is changed.
+// This is called only when the state is changed by the user so that clr is not rounded by the conversions.
+func (st *colorPickerState) apply(clr *color.RGBA) bool {
+	st.clr = st.rgba()
+	if *clr == st.clr {
+		return false
+	}
+	*clr = st.clr
+	return true
+}
+
+// parseHexColor parses a non-premultiplied color in the form of #RRGGBB or #RRGGBBAA.
+// The leading # is optional.
+func parseHexColor(str string) (color.NRGBA, bool) {
+	str = strings.TrimPrefix(strings.TrimSpace(str), "#")
+	if len(str) != 6 && len(str) != 8 {
+		return color.NRGBA{}, false
+	}
+	v, err := strconv.ParseUint(str, 16, 32)
+	if err != nil {
+		return color.NRGBA{}, false
+	}
+	if len(str) == 6 {
+		v = v<<8 | 0xff
+	}
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, true
+}
+
+// colorPickerState returns the state of the color picker of the ID, updated with clr.
+func (c *Context) colorPickerState(id ID, clr color.RGBA) *colorPickerState {
+	if c.colorPickers == nil {
+		c.colorPickers = map[ID]*colorPickerState{}
+	}
+	st, ok := c.colorPickers[id]
+	if !ok {
+		st = &colorPickerState{}
+		c.colorPickers[id] = st
+	}
+	st.used = true
+	// the color might be changed by the caller.
+	if !ok || st.clr != clr {
+		st.setColor(clr)
+	}
+	return st
+}
+
+// pruneColorPickers removes the states of the color pickers that are not updated in this frame.
+func (c *Context) pruneColorPickers() {
+	for id, st := range c.colorPickers {
+		if !st.used {
+			delete(c.colorPickers, id)
+			continue
+		}
+		st.used = false
+	}
+}
+
+// colorPickerSquareHeight returns the height of the saturation/value square of a color picker.
+func (c *Context) colorPickerSquareHeight() int {
+	return c.defaultRowHeight() * colorPickerSquareRows
+}
+
+// colorPickerHeight returns the height of a color picker.
+func (c *Context) colorPickerHeight() int {
+	return c.colorPickerSquareHeight() + c.style.spacing + c.defaultRowHeight()
+}
+
+// drawCheckerboard draws the checkerboard shown under translucent colors.
+func (c *Context) drawCheckerboard(rect image.Rectangle) {
+	c.drawRect(rect, colorCheckerLight)
+	for y := rect.Min.Y; y < rect.Max.Y; y += colorCheckerSize {
+		x0 := rect.Min.X
+		if ((y-rect.Min.Y)/colorCheckerSize)%2 == 1 {
+			x0 += colorCheckerSize
+		}
+		for x := x0; x < rect.Max.X; x += colorCheckerSize * 2 {
+			c.drawRect(image.Rect(x, y, x+colorCheckerSize, y+colorCheckerSize).Intersect(rect), colorCheckerDark)
+		}
+	}
+}
+
+// drawColorSwatch draws the premultiplied color over the checkerboard.
+func (c *Context) drawColorSwatch(rect image.Rectangle, clr color.RGBA) {
+	if clr.A != 0xff {
+		c.drawCheckerboard(rect)
+	}
+	c.drawRect(rect, clr)
+}
+
+// drawColorMarker draws the marker of the current position in a gradient so that it is visible on any color.
+func (c *Context) drawColorMarker(rect image.Rectangle, radius int) {
+	outerRadius := radius
+	if radius > 0 {
+		outerRadius++
+	}
+	c.drawOutline(rect.Inset(-1), outerRadius, 1, colorMarkerOuter)
+	c.drawOutline(rect, radius, 1, colorMarkerInner)
+}
+
+func (c *Context) colorPicker(clr *color.RGBA) Response {
+	id := c.pushID(ptrToBytes(unsafe.Pointer(clr)))
+	defer c.popID()
+
+	var res Response
+	c.LayoutColumn(func() {
+		res = c.colorPickerControls(c.colorPickerState(id, *clr), clr)
+	})
+	return res
+}
+
+// colorPickerControls does the controls of a color picker: the saturation/value square, the hue bar,
+// the alpha bar, the hex text entry and the preview.
+func (c *Context) colorPickerControls(st *colorPickerState, clr *color.RGBA) Response {
+	var res Response
+	bar := c.defaultRowHeight()
+	sp := c.style.spacing
+
+	c.SetLayoutRow([]int{-(bar+sp)*2 - 1, bar, -1}, c.colorPickerSquareHeight())
+
+	// the saturation goes from left to right, and the value goes from bottom to top.
+	svID := c.id([]byte("!sv"))
+	res |= c.Control(svID, 0, func(r image.Rectangle) Response {
+		var res Response
+		s, v := st.s, st.v
+		if c.focus == svID && (c.mouseDown|c.mousePressed) == mouseLeft {
+			s = float64(c.mousePos.X-r.Min.X) / float64(max(r.Dx(), 1))
+			v = 1 - float64(c.mousePos.Y-r.Min.Y)/float64(max(r.Dy(), 1))
+		}
+		if c.navFocus == svID {
+			if (c.keyPressed & keyArrowRight) != 0 {
+				s += colorPickerKeyStep
+			}
+			if (c.keyPressed & keyArrowLeft) != 0 {
+				s -= colorPickerKeyStep
+			}
+			if (c.keyPressed & keyArrowUp) != 0 {
+				v += colorPickerKeyStep
+			}
+			if (c.keyPressed & keyArrowDown) != 0 {
+				v -= colorPickerKeyStep
+			}
+		}
+		if s, v := clampF(s, 0, 1), clampF(v, 0, 1); s != st.s || v != st.v {
+			st.s, st.v = s, v
+			if st.apply(clr) {
+				res |= ResponseChange
+			}
+		}
+
+		// draw. the value is applied by darkening the strips of the saturation with black.
+		c.drawControlFrame(svID, r, ColorBase, 0)
+		for x := r.Min.X; x < r.Max.X; x += colorPickerStripWidth {
+			s := float64(x-r.Min.X) / float64(max(r.Dx()-1, 1))
+			c.drawRect(image.Rect(x, r.Min.Y, min(x+colorPickerStripWidth, r.Max.X), r.Max.Y), hsvaToRGBA(st.h, s, 1, 1))
+		}
+		for y := r.Min.Y; y < r.Max.Y; y += colorPickerStripWidth {
+			a := float64(y-r.Min.Y) / float64(max(r.Dy()-1, 1))
+			c.drawRect(image.Rect(r.Min.X, y, r.Max.X, min(y+colorPickerStripWidth, r.Max.Y)), color.RGBA{0, 0, 0, uint8(a*0xff + 0.5)})
+		}
+		p := image.Pt(r.Min.X+int(st.s*float64(r.Dx())), r.Min.Y+int((1-st.v)*float64(r.Dy())))
+		c.drawColorMarker(image.Rect(p.X-3, p.Y-3, p.X+4, p.Y+4), 3)
+		return res
+	})
+
+	// the hue and the alpha go from bottom to top so that the arrow keys move the markers.
+	hueID := c.id([]byte("!hue"))
+	res |= c.Control(hueID, 0, func(r image.Rectangle) Response {
+		var res Response
+		h := st.h
+		if c.focus == hueID && (c.mouseDown|c.mousePressed) == mouseLeft {
+			h = (1 - float64(c.mousePos.Y-r.Min.Y)/float64(max(r.Dy(), 1))) * 360
+		}
+		h += float64(c.navStep(hueID)) * colorPickerKeyStep * 360
+		if h := clampF(h, 0, 360); h != st.h {
+			st.h = h
+			if st.apply(clr) {
+				res |= ResponseChange
+			}
+		}
+
+		// draw
+		c.drawControlFrame(hueID, r, ColorBase, 0)
+		for y := r.Min.Y; y < r.Max.Y; y += colorPickerStripWidth {
+			h := (1 - float64(y-r.Min.Y)/float64(max(r.Dy()-1, 1))) * 360
+			c.drawRect(image.Rect(r.Min.X, y, r.Max.X, min(y+colorPickerStripWidth, r.Max.Y)), hsvaToRGBA(h, 1, 1, 1))
+		}
+		y := r.Min.Y + int((1-st.h/360)*float64(r.Dy()))
+		c.drawColorMarker(image.Rect(r.Min.X, y-2, r.Max.X, y+2), 0)
+		return res
+	})
+
+	alphaID := c.id([]byte("!alpha"))
+	res |= c.Control(alphaID, 0, func(r image.Rectangle) Response {
+		var res Response
+		a := st.a
+		if c.focus == alphaID && (c.mouseDown|c.mousePressed) == mouseLeft {
+			a = 1 - float64(c.mousePos.Y-r.Min.Y)/float64(max(r.Dy(), 1))
+		}
+		a += float64(c.navStep(alphaID)) * colorPickerKeyStep
+		if a := clampF(a, 0, 1); a != st.a {
+			st.a = a
+			if st.apply(clr) {
+				res |= ResponseChange
+			}
+		}
+
+		// draw
+		c.drawControlFrame(alphaID, r, ColorBase, 0)
+		c.drawCheckerboard(r)
+		for y := r.Min.Y; y < r.Max.Y; y += colorPickerStripWidth {
+			a := 1 - float64(y-r.Min.Y)/float64(max(r.Dy()-1, 1))
+			c.drawRect(image.Rect(r.Min.X, y, r.Max.X, min(y+colorPickerStripWidth, r.Max.Y)), hsvaToRGBA(st.h, st.s, st.v, a))
+		}
+		y := r.Min.Y + int((1-st.a)*float64(r.Dy()))
+		c.drawColorMarker(image.Rect(r.Min.X, y-2, r.Max.X, y+2), 0)
+		return res
+	})
+
+	c.SetLayoutRow([]int{-bar*2 - sp - 1, -1}, 0)
+
+	// the hex text is updated only while it is not edited.
+	hexID := c.id([]byte("!hex"))
+	if c.focus != hexID {
+		st.hex = st.hexString()
+	}
+	if c.textBoxRaw(&st.hex, hexID, 0)&(ResponseChange|ResponseSubmit) != 0 {
+		if n, ok := parseHexColor(st.hex); ok {
+			st.setNRGBA(n)
+			if st.apply(clr) {
+				res |= ResponseChange
+			}
+		}
+	}
+
+	c.Control(0, 0, func(r image.Rectangle) Response {
+		c.drawColorSwatch(r, *clr)
+		return 0
+	})
+	return res
+}
+
+func (c *Context) colorEdit(label string, clr *color.RGBA, opt option) Response {
+	id := c.pushID(ptrToBytes(unsafe.Pointer(clr)))
+	defer c.popID()
+
+	open := c.dropdownOpen()
+
+	var box image.Rectangle
+	var toggled bool
+	res := c.Control(id, opt, func(r image.Rectangle) Response {
+		box = r
+		if len(label) > 0 {
+			box.Max.X = max(box.Min.X+box.Dy(), box.Max.X-c.textWidth(label)-c.style.padding*2)
+		}
+
+		// handle click
+		if (c.mousePressed == mouseLeft && c.focus == id) || (!open && c.navActivated(id)) {
+			toggled = true
+		}
+
+		// draw
+		c.drawControlFrame(id, box, ColorBase, opt)
+		swatch := image.Rect(box.Min.X, box.Min.Y, box.Min.X+box.Dy()*2, box.Max.Y).Inset(c.style.padding / 2)
+		c.drawColorSwatch(swatch, *clr)
+		n := color.NRGBAModel.Convert(*clr).(color.NRGBA)
+		hex := fmt.Sprintf("#%02X%02X%02X%02X", n.R, n.G, n.B, n.A)
+		c.drawControlText(hex, image.Rect(swatch.Max.X, box.Min.Y, box.Max.X, box.Max.Y), ColorText, 0)
+		if len(label) > 0 {
+			c.drawControlText(label, image.Rect(box.Max.X, r.Min.Y, r.Max.X, r.Max.Y), ColorText, 0)
+		}
+		return 0
+	})
+
+	bar := c.defaultRowHeight()
+	w := c.colorPickerSquareHeight() + (bar+c.style.spacing)*2 + c.style.padding*2
+	h := c.colorPickerHeight() + c.style.padding*2
+	c.dropdownPopup(box, toggled, image.Pt(w, h), func() {
+		c.SetLayoutRow([]int{-1}, 0)
+		res |= c.colorPicker(clr)
+	})
+	return res
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestParseHexColor(t *testing.T) {
+	testCases := []struct {
+		str  string
+		want color.NRGBA
+		ok   bool
+	}{
+		{str: "#FF8000", want: color.NRGBA{R: 0xff, G: 0x80, B: 0x00, A: 0xff}, ok: true},
+		{str: "#ff800040", want: color.NRGBA{R: 0xff, G: 0x80, B: 0x00, A: 0x40}, ok: true},
+		{str: "FF8000", want: color.NRGBA{R: 0xff, G: 0x80, B: 0x00, A: 0xff}, ok: true},
+		{str: " #FF8000 ", want: color.NRGBA{R: 0xff, G: 0x80, B: 0x00, A: 0xff}, ok: true},
+		{str: "#F80", ok: false},
+		{str: "#F80F", ok: false},
+		{str: "#FF80000", ok: false},
+		{str: "", ok: false},
+		{str: "#", ok: false},
+		{str: "#GG8000", ok: false},
+		{str: "0x8000", ok: false},
+		{str: "+F8000", ok: false},
+		{str: "##FF8000", ok: false},
+	}
+	for _, tc := range testCases {
+		got, ok := parseHexColor(tc.str)
+		if ok != tc.ok || got != tc.want {
+			t.Errorf("parseHexColor(%q) = (%v, %t), want (%v, %t)", tc.str, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestHSVToRGB(t *testing.T) {
+	testCases := []struct {
+		h, s, v float64
+		r, g, b float64
+	}{
+		{h: 0, s: 1, v: 1, r: 1, g: 0, b: 0},
+		{h: 60, s: 1, v: 1, r: 1, g: 1, b: 0},
+		{h: 120, s: 1, v: 1, r: 0, g: 1, b: 0},
+		{h: 240, s: 1, v: 1, r: 0, g: 0, b: 1},
+		{h: 360, s: 1, v: 1, r: 1, g: 0, b: 0},
+		{h: -120, s: 1, v: 1, r: 0, g: 0, b: 1},
+		{h: 30, s: 0, v: 0.5, r: 0.5, g: 0.5, b: 0.5},
+		{h: 200, s: 1, v: 0, r: 0, g: 0, b: 0},
+	}
+	for _, tc := range testCases {
+		r, g, b := hsvToRGB(tc.h, tc.s, tc.v)
+		if math.Abs(r-tc.r) > 1e-9 || math.Abs(g-tc.g) > 1e-9 || math.Abs(b-tc.b) > 1e-9 {
+			t.Errorf("hsvToRGB(%v, %v, %v) = (%v, %v, %v), want (%v, %v, %v)", tc.h, tc.s, tc.v, r, g, b, tc.r, tc.g, tc.b)
+		}
+	}
+}
+
+func TestColorPickerStateRoundTrip(t *testing.T) {
+	for r := 0; r <= 0xff; r += 0x33 {
+		for g := 0; g <= 0xff; g += 0x33 {
+			for b := 0; b <= 0xff; b += 0x33 {
+				for _, a := range []uint8{0x80, 0xff} {
+					n := color.NRGBA{R: uint8(r), G: uint8(g), B: uint8(b), A: a}
+					var st colorPickerState
+					st.setNRGBA(n)
+					got, ok := parseHexColor(st.hexString())
+					if !ok || got != n {
+						t.Errorf("the color %v through HSV: got %v (%t)", n, got, ok)
+					}
+					if want := color.RGBAModel.Convert(n).(color.RGBA); !closeRGBA(st.rgba(), want) {
+						t.Errorf("the premultiplied color %v through HSV: got %v, want %v", n, st.rgba(), want)
+					}
+				}
+			}
+		}
+	}
+}
+
+// closeRGBA reports whether the colors are the same except for the rounding.
+func closeRGBA(a, b color.RGBA) bool {
+	return abs(int(a.R)-int(b.R)) <= 1 && abs(int(a.G)-int(b.G)) <= 1 && abs(int(a.B)-int(b.B)) <= 1 && a.A == b.A
+}
+
+func TestColorPickerStatePruned(t *testing.T) {
+	d := newTestUI(newTestInput())
+	clr := color.RGBA{R: 0xff, A: 0xff}
+	shown := true
+	f := func(ctx *Context) {
+		ctx.Window("Window", image.Rect(10, 10, 310, 310), func(res Response, layout Layout) {
+			if shown {
+				ctx.ColorPicker(&clr)
+			}
+		})
+	}
+	d.Update(f)
+	if got := len(d.ctx.colorPickers); got != 1 {
+		t.Fatalf("the color picker states while the picker is shown: got %d, want 1", got)
+	}
+	d.Update(f)
+	if got := len(d.ctx.colorPickers); got != 1 {
+		t.Errorf("the color picker states in the next frame: got %d, want 1", got)
+	}
+
+	shown = false
+	d.Update(f)
+	if got := len(d.ctx.colorPickers); got != 0 {
+		t.Errorf("the color picker states after the picker is hidden: got %d, want 0", got)
+	}
+}
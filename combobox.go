@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"unsafe"
+)
+
+// comboBoxMaxVisibleItems is the maximum number of items shown in the popup of a combo box without scrolling.
+const comboBoxMaxVisibleItems = 8
+
+func (c *Context) comboBox(label string, index *int, items []string, opt option) Response {
+	id := c.pushID(ptrToBytes(unsafe.Pointer(index)))
+	defer c.popID()
+
+	open := c.dropdownOpen()
+
+	var box image.Rectangle
+	var toggled bool
+	res := c.Control(id, opt, func(r image.Rectangle) Response {
+		var res Response
+		box = r
+		if len(label) > 0 {
+			box.Max.X = max(box.Min.X+box.Dy(), box.Max.X-c.textWidth(label)-c.style.padding*2)
+		}
+
+		// handle click. while the popup is open, the keys are handled by the popup.
+		if (c.mousePressed == mouseLeft && c.focus == id) || (!open && c.navActivated(id)) {
+			toggled = true
+		}
+		// the arrow keys change the selection without opening the popup.
+		if !open && c.navFocus == id && len(items) > 0 {
+			idx := *index
+			if (c.keyPressed & keyArrowDown) != 0 {
+				idx++
+			}
+			if (c.keyPressed & keyArrowUp) != 0 {
+				idx--
+			}
+			idx = clamp(idx, 0, len(items)-1)
+			if idx != *index {
+				*index = idx
+				res |= ResponseChange
+			}
+		}
+
+		// draw
+		c.drawControlFrame(id, box, ColorBase, opt)
+		arrow := image.Rect(box.Max.X-box.Dy(), box.Min.Y, box.Max.X, box.Max.Y)
+		if *index >= 0 && *index < len(items) {
+			c.drawControlText(items[*index], image.Rect(box.Min.X, box.Min.Y, arrow.Min.X, box.Max.Y), ColorText, 0)
+		}
+		c.drawIcon(IconExpanded, arrow, c.style.colors[ColorText])
+		if len(label) > 0 {
+			c.drawControlText(label, image.Rect(box.Max.X, r.Min.Y, r.Max.X, r.Max.Y), ColorText, 0)
+		}
+		return res
+	})
+
+	if toggled && !open {
+		c.comboHighlight = *index
+	}
+	n := min(len(items), comboBoxMaxVisibleItems)
+	h := max(n*c.defaultRowHeight()+(n-1)*c.style.spacing, 0) + c.style.padding*2
+	c.dropdownPopup(box, toggled, image.Pt(0, h), func() {
+		res |= c.comboBoxItems(index, items)
+	})
+	return res
+}
+
+// comboBoxItems does the items in the popup of a combo box.
+func (c *Context) comboBoxItems(index *int, items []string) Response {
+	var res Response
+	if len(items) == 0 {
+		return res
+	}
+	popup := c.currentContainer()
+
+	// handle keys
+	var moved bool
+	if (c.keyPressed & keyArrowDown) != 0 {
+		c.comboHighlight++
+		moved = true
+	}
+	if (c.keyPressed & keyArrowUp) != 0 {
+		c.comboHighlight--
+		moved = true
+	}
+	c.comboHighlight = clamp(c.comboHighlight, 0, len(items)-1)
+	selected := -1
+	if (c.keyPressed & keyReturn) != 0 {
+		selected = c.comboHighlight
+	}
+
+	c.SetLayoutRow([]int{-1}, 0)
+	for i := range items {
+		itemID := c.id(ptrToBytes(unsafe.Pointer(&items[i])))
+		c.Control(itemID, 0, func(r image.Rectangle) Response {
+			// the mouse moves the highlight only when it moves so that it doesn't fight with the keys.
+			if c.hover == itemID && c.mouseDelta != (image.Point{}) {
+				c.comboHighlight = i
+			}
+			if (c.mousePressed == mouseLeft && c.focus == itemID) || c.navActivated(itemID) {
+				selected = i
+			}
+			// scroll the highlighted item into view
+			if moved && i == c.comboHighlight {
+				body := popup.layout.Body
+				if r.Min.Y < body.Min.Y {
+					popup.layout.Scroll.Y -= body.Min.Y - r.Min.Y
+				} else if r.Max.Y > body.Max.Y {
+					popup.layout.Scroll.Y += r.Max.Y - body.Max.Y
+				}
+			}
+
+			// draw
+			if i == c.comboHighlight {
+				c.drawFrame(r, ColorButtonHover)
+			} else if i == *index {
+				c.drawFrame(r, ColorButton)
+			}
+			c.drawControlText(items[i], r, ColorText, 0)
+			return 0
+		})
+	}
+
+	if selected >= 0 {
+		if *index != selected {
+			*index = selected
+			res |= ResponseChange
+		}
+		popup.open = false
+	}
+	return res
+}
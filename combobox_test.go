@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestComboBoxPopup(t *testing.T) {
+	input := newTestInput()
+	d := newTestUI(input)
+	index := 0
+	items := []string{"a", "b", "c"}
+	var box image.Rectangle
+	f := func(ctx *Context) {
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(res Response, layout Layout) {
+			ctx.ComboBox("Combo", &index, items)
+			// lastRect is the last item in the popup while the popup is open.
+			if box.Empty() {
+				box = ctx.lastRect
+			}
+		})
+	}
+	d.Update(f)
+	open := func() bool {
+		for _, cnt := range d.ctx.rootList {
+			if cnt.open && cnt.layout.Rect.Min == image.Pt(box.Min.X, box.Max.Y) {
+				return true
+			}
+		}
+		return false
+	}
+
+	click(d, input, box.Min.Add(image.Pt(2, 2)), f)
+	if !open() {
+		t.Fatalf("the popup is not open after a click")
+	}
+	press(d, input, ebiten.KeyArrowDown, f)
+	press(d, input, ebiten.KeyEnter, f)
+	if open() {
+		t.Errorf("the popup is open after Enter")
+	}
+	if index != 1 {
+		t.Errorf("index: got %d, want 1", index)
+	}
+
+	click(d, input, box.Min.Add(image.Pt(2, 2)), f)
+	press(d, input, ebiten.KeyArrowDown, f)
+	press(d, input, ebiten.KeyEscape, f)
+	if open() {
+		t.Errorf("the popup is open after Escape")
+	}
+	if index != 1 {
+		t.Errorf("index after Escape: got %d, want 1", index)
+	}
+}
@@ -10,37 +10,56 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
-// pushCommand adds a new command with type cmd_type to command_list
+// pushCommand adds a new command with type cmd_type to command_list.
+//
+// The returned command is valid until the next pushCommand call.
+// If the command list is full, the command is dropped and the returned command is a scratch one.
+// Jump commands are never dropped so that the root containers are still linked.
 func (c *Context) pushCommand(cmd_type int) *command {
-	cmd := command{
-		typ: cmd_type,
+	if len(c.commandList) >= commandListSize && cmd_type != commandJump {
+		c.droppedCount++
+		c.dropped = command{}
+		return &c.dropped
 	}
-	//expect(uintptr(len(ctx.CommandList))*size+size < MU_COMMANDLIST_SIZE)
-	cmd.base.typ = cmd_type
-	cmd.idx = len(c.commandList)
-	c.commandList = append(c.commandList, &cmd)
-	return &cmd
+	c.commandList = append(c.commandList, command{
+		typ: cmd_type,
+		idx: len(c.commandList),
+	})
+	return &c.commandList[len(c.commandList)-1]
 }
 
 func (c *Context) nextCommand(cmd **command) bool {
 	if len(c.commandList) == 0 {
 		return false
 	}
-	if *cmd == nil {
-		*cmd = c.commandList[0]
-	} else {
-		*cmd = c.commandList[(*cmd).idx+1]
+	idx := 0
+	if *cmd != nil {
+		idx = (*cmd).idx + 1
 	}
-
-	for (*cmd).idx < len(c.commandList) {
-		if (*cmd).typ != commandJump {
+	for idx < len(c.commandList) {
+		next := &c.commandList[idx]
+		if next.typ != commandJump {
+			*cmd = next
 			return true
 		}
-		idx := (*cmd).jump.dstIdx
-		if idx > len(c.commandList)-1 {
-			break
+		idx = next.dstIdx
+	}
+	return false
+}
+
+// nextCommandInRange is like nextCommand but iterates over the commands from the index start until it reaches the index end.
+func (c *Context) nextCommandInRange(cmd **command, start, end int) bool {
+	idx := start
+	if *cmd != nil {
+		idx = (*cmd).idx + 1
+	}
+	for idx < end && idx < len(c.commandList) {
+		next := &c.commandList[idx]
+		if next.typ != commandJump {
+			*cmd = next
+			return true
 		}
-		*cmd = c.commandList[idx]
+		idx = next.dstIdx
 	}
 	return false
 }
@@ -48,33 +67,101 @@ func (c *Context) nextCommand(cmd **command) bool {
 // pushJump pushes a new jump command to command_list
 func (c *Context) pushJump(dstIdx int) int {
 	cmd := c.pushCommand(commandJump)
-	cmd.jump.dstIdx = dstIdx
+	cmd.dstIdx = dstIdx
 	return len(c.commandList) - 1
 }
 
 func (c *Context) setClip(rect image.Rectangle) {
 	cmd := c.pushCommand(commandClip)
-	cmd.clip.rect = rect
+	cmd.rect = rect
 }
 
-func (c *Context) drawRect(rect image.Rectangle, color color.Color) {
+func (c *Context) drawRect(rect image.Rectangle, color color.RGBA) {
 	rect2 := rect.Intersect(c.clipRect())
 	if rect2.Dx() > 0 && rect2.Dy() > 0 {
 		cmd := c.pushCommand(commandRect)
-		cmd.rect.rect = rect2
-		cmd.rect.color = color
+		cmd.rect = rect2
+		cmd.color = c.windowColor(color)
+	}
+}
+
+// drawRoundedRect fills the rectangle with rounded corners.
+// If radius is 0, drawRoundedRect is the same as drawRect.
+func (c *Context) drawRoundedRect(rect image.Rectangle, radius int, color color.RGBA) {
+	if radius <= 0 {
+		c.drawRect(rect, color)
+		return
+	}
+	clipped := c.checkClip(rect)
+	if clipped == clipAll {
+		return
+	}
+	if clipped == clipPart {
+		c.setClip(c.clipRect())
+	}
+	cmd := c.pushCommand(commandRoundedRect)
+	cmd.rect = rect
+	cmd.radius = radius
+	cmd.color = c.windowColor(color)
+	if clipped != 0 {
+		c.setClip(unclippedRect)
+	}
+}
+
+// drawOutline draws the border of the given width inside the rectangle with rounded corners.
+// If radius is 0, the border is drawn with rectangles.
+func (c *Context) drawOutline(rect image.Rectangle, radius, width int, color color.RGBA) {
+	if width <= 0 {
+		return
+	}
+	if radius <= 0 {
+		c.drawRect(image.Rect(rect.Min.X+width, rect.Min.Y, rect.Max.X-width, rect.Min.Y+width), color)
+		c.drawRect(image.Rect(rect.Min.X+width, rect.Max.Y-width, rect.Max.X-width, rect.Max.Y), color)
+		c.drawRect(image.Rect(rect.Min.X, rect.Min.Y, rect.Min.X+width, rect.Max.Y), color)
+		c.drawRect(image.Rect(rect.Max.X-width, rect.Min.Y, rect.Max.X, rect.Max.Y), color)
+		return
+	}
+	clipped := c.checkClip(rect)
+	if clipped == clipAll {
+		return
+	}
+	if clipped == clipPart {
+		c.setClip(c.clipRect())
+	}
+	cmd := c.pushCommand(commandOutline)
+	cmd.rect = rect
+	cmd.radius = radius
+	cmd.width = width
+	cmd.color = c.windowColor(color)
+	if clipped != 0 {
+		c.setClip(unclippedRect)
 	}
 }
 
-func (c *Context) drawBox(rect image.Rectangle, color color.Color) {
-	c.drawRect(image.Rect(rect.Min.X+1, rect.Min.Y, rect.Max.X-1, rect.Min.Y+1), color)
-	c.drawRect(image.Rect(rect.Min.X+1, rect.Max.Y-1, rect.Max.X-1, rect.Max.Y), color)
-	c.drawRect(image.Rect(rect.Min.X, rect.Min.Y, rect.Min.X+1, rect.Max.Y), color)
-	c.drawRect(image.Rect(rect.Max.X-1, rect.Min.Y, rect.Max.X, rect.Max.Y), color)
+// drawShadow draws a soft shadow of the given size around the rectangle with rounded corners.
+func (c *Context) drawShadow(rect image.Rectangle, radius, size int, color color.RGBA) {
+	if size <= 0 || color.A == 0 {
+		return
+	}
+	clipped := c.checkClip(rect.Inset(-size))
+	if clipped == clipAll {
+		return
+	}
+	if clipped == clipPart {
+		c.setClip(c.clipRect())
+	}
+	cmd := c.pushCommand(commandShadow)
+	cmd.rect = rect
+	cmd.radius = radius
+	cmd.width = size
+	cmd.color = c.windowColor(color)
+	if clipped != 0 {
+		c.setClip(unclippedRect)
+	}
 }
 
-func (c *Context) drawText(str string, pos image.Point, color color.Color) {
-	rect := image.Rect(pos.X, pos.Y, pos.X+textWidth(str), pos.Y+lineHeight())
+func (c *Context) drawText(str string, pos image.Point, color color.RGBA) {
+	rect := image.Rect(pos.X, pos.Y, pos.X+c.textWidth(str), pos.Y+c.lineHeight())
 	clipped := c.checkClip(rect)
 	if clipped == clipAll {
 		return
@@ -84,16 +171,18 @@ func (c *Context) drawText(str string, pos image.Point, color color.Color) {
 	}
 	// add command
 	cmd := c.pushCommand(commandText)
-	cmd.text.str = str
-	cmd.text.pos = pos
-	cmd.text.color = color
+	cmd.str = str
+	cmd.font = c.currentFont()
+	cmd.face = c.face()
+	cmd.rect = image.Rectangle{Min: pos}
+	cmd.color = c.windowColor(color)
 	// reset clipping if it was set
 	if clipped != 0 {
 		c.setClip(unclippedRect)
 	}
 }
 
-func (c *Context) drawIcon(icon icon, rect image.Rectangle, color color.Color) {
+func (c *Context) drawIcon(icon Icon, rect image.Rectangle, color color.RGBA) {
 	// do clip command if the rect isn't fully contained within the cliprect
 	clipped := c.checkClip(rect)
 	if clipped == clipAll {
@@ -104,9 +193,9 @@ func (c *Context) drawIcon(icon icon, rect image.Rectangle, color color.Color) {
 	}
 	// do icon command
 	cmd := c.pushCommand(commandIcon)
-	cmd.icon.icon = icon
-	cmd.icon.rect = rect
-	cmd.icon.color = color
+	cmd.icon = icon
+	cmd.rect = rect
+	cmd.color = c.windowColor(color)
 	// reset clipping if it was set
 	if clipped != 0 {
 		c.setClip(unclippedRect)
@@ -117,5 +206,7 @@ func (c *Context) Draw(f func(screen *ebiten.Image)) {
 	c.setClip(c.clipRect())
 	defer c.setClip(unclippedRect)
 	cmd := c.pushCommand(commandDraw)
-	cmd.draw.f = f
+	cmd.f = f
+	// the color scales the drawing with the opacity of the window.
+	cmd.color = c.windowColor(color.RGBA{0xff, 0xff, 0xff, 0xff})
 }
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"testing"
+)
+
+func TestCommandListOverflow(t *testing.T) {
+	d := newTestUI(newTestInput())
+	d.Update(func(ctx *Context) {
+		ctx.Window("Big", image.Rect(0, 0, 200, 200), func(res Response, layout Layout) {
+			ctx.Control(0, 0, func(r image.Rectangle) Response {
+				for i := 0; i < commandListSize; i++ {
+					ctx.drawRect(r, ctx.style.colors[ColorText])
+				}
+				return 0
+			})
+		})
+		ctx.Window("Small", image.Rect(200, 0, 400, 200), func(res Response, layout Layout) {
+			ctx.Label("Label")
+		})
+	})
+	if d.DroppedCommands() == 0 {
+		t.Errorf("DroppedCommands() = 0, want > 0")
+	}
+
+	// the frame is still valid: the iteration reaches the end.
+	var n int
+	for it := d.Commands(); it.Next(); {
+		n++
+	}
+	if n > commandListSize {
+		t.Errorf("got %d commands, want <= %d", n, commandListSize)
+	}
+
+	d.Update(func(ctx *Context) {
+		ctx.Window("Small", image.Rect(200, 0, 400, 200), func(res Response, layout Layout) {
+			ctx.Label("Label")
+		})
+	})
+	if got := d.DroppedCommands(); got != 0 {
+		t.Errorf("DroppedCommands() = %d, want 0", got)
+	}
+}
+
+func TestUpdateAllocs(t *testing.T) {
+	d := newTestUI(newTestInput())
+	checked := true
+	value := 0.5
+	buf := "text"
+	f := func(ctx *Context) {
+		ctx.Window("Window", image.Rect(0, 0, 300, 400), func(res Response, layout Layout) {
+			ctx.SetLayoutRow([]int{-1}, 0)
+			ctx.Label("Label")
+			ctx.Button("Button")
+			ctx.Checkbox("Checkbox", &checked)
+			ctx.Slider(&value, 0, 1, 0.1, 1)
+			ctx.TextBox(&buf)
+		})
+	}
+	// warm up the pools and the buffers.
+	for i := 0; i < 3; i++ {
+		d.Update(f)
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		d.Update(f)
+	})
+	// the slider formats its value into a new string every frame.
+	if allocs > 1 {
+		t.Errorf("Update allocates %v times, want <= 1", allocs)
+	}
+}
@@ -9,7 +9,7 @@ import (
 )
 
 const (
-	commandListSize    = 256 * 1024
+	commandListSize    = 256 * 1024 // the maximum number of commands in a frame
 	rootListSize       = 32
 	containerStackSize = 32
 	clipStackSize      = 32
@@ -17,6 +17,7 @@ const (
 	layoutStackSize    = 16
 	containerPoolSize  = 48
 	treeNodePoolSize   = 48
+	listBoxPoolSize    = 48
 	maxWidths          = 16
 )
 
@@ -33,6 +34,7 @@ var defaultStyle style = style{
 	titleHeight:   24,
 	scrollbarSize: 12,
 	thumbSize:     8,
+	borderWidth:   1,
 	colors: [...]color.RGBA{
 		{230, 230, 230, 255}, // MU_COLOR_TEXT
 		{25, 25, 25, 255},    // MU_COLOR_BORDER
@@ -48,6 +50,9 @@ var defaultStyle style = style{
 		{40, 40, 40, 255},    // MU_COLOR_BASEFOCUS
 		{43, 43, 43, 255},    // MU_COLOR_SCROLLBASE
 		{30, 30, 30, 255},    // MU_COLOR_SCROLLTHUMB
+		{200, 200, 200, 255}, // MU_COLOR_FOCUSRING
+		{60, 90, 140, 255},   // MU_COLOR_SELECTION
+		{0, 0, 0, 96},        // MU_COLOR_SHADOW
 	},
 }
 
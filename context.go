@@ -8,7 +8,7 @@ import (
 )
 
 func (c *Context) drawFrame(rect image.Rectangle, colorid int) {
-	c.drawRect(rect, c.style.colors[colorid])
+	c.drawRoundedRect(rect, c.style.cornerRadius, c.style.colors[colorid])
 	if colorid == ColorScrollBase ||
 		colorid == ColorScrollThumb ||
 		colorid == ColorTitleBG {
@@ -17,6 +17,16 @@ func (c *Context) drawFrame(rect image.Rectangle, colorid int) {
 
 	// draw border
 	if c.style.colors[ColorBorder].A != 0 {
-		c.drawBox(rect.Inset(-1), c.style.colors[ColorBorder])
+		w := c.style.borderWidth
+		r := c.style.cornerRadius
+		if r > 0 {
+			r += w
+		}
+		c.drawOutline(rect.Inset(-w), r, w, c.style.colors[ColorBorder])
 	}
 }
+
+// windowBounds returns the bounds of the root container including its border and shadow.
+func (c *Context) windowBounds(cnt *container) image.Rectangle {
+	return cnt.layout.Rect.Inset(-max(1, c.style.borderWidth, c.style.shadowSize))
+}
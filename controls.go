@@ -11,13 +11,15 @@ import (
 	"strconv"
 	"unicode/utf8"
 	"unsafe"
-
-	"github.com/hajimehoshi/ebiten/v2/exp/textinput"
 )
 
 func (c *Context) inHoverRoot() bool {
+	return c.inRoot(c.hoverRoot)
+}
+
+func (c *Context) inRoot(root *container) bool {
 	for i := len(c.containerStack) - 1; i >= 0; i-- {
-		if c.containerStack[i] == c.hoverRoot {
+		if c.containerStack[i] == root {
 			return true
 		}
 		// only root containers have their `head` field set; stop searching if we've
@@ -43,9 +45,9 @@ func (c *Context) drawControlFrame(id ID, rect image.Rectangle, colorid int, opt
 
 func (c *Context) drawControlText(str string, rect image.Rectangle, colorid int, opt option) {
 	var pos image.Point
-	tw := textWidth(str)
+	tw := c.textWidth(str)
 	c.pushClipRect(rect)
-	pos.Y = rect.Min.Y + (rect.Dy()-lineHeight())/2
+	pos.Y = rect.Min.Y + (rect.Dy()-c.lineHeight())/2
 	if (opt & optionAlignCenter) != 0 {
 		pos.X = rect.Min.X + (rect.Dx()-tw)/2
 	} else if (opt & optionAlignRight) != 0 {
@@ -99,14 +101,23 @@ func (c *Context) updateControl(id ID, rect image.Rectangle, opt option) {
 func (c *Context) Control(id ID, opt option, f func(r image.Rectangle) Response) Response {
 	r := c.layoutNext()
 	c.updateControl(id, r, opt)
-	return f(r)
+	c.updateNavControl(id, r, opt)
+	res := f(r)
+	if id != 0 && c.navFocus == id {
+		radius := c.style.cornerRadius
+		if radius > 0 {
+			radius += 2
+		}
+		c.drawOutline(r.Inset(-2), radius, 1, c.style.colors[ColorFocusRing])
+	}
+	return res
 }
 
 func (c *Context) Text(text string) {
 	color := c.style.colors[ColorText]
 	c.LayoutColumn(func() {
 		var endIdx, p int
-		c.SetLayoutRow([]int{-1}, lineHeight())
+		c.SetLayoutRow([]int{-1}, c.lineHeight())
 		for endIdx < len(text) {
 			c.Control(0, 0, func(r image.Rectangle) Response {
 				w := 0
@@ -117,12 +128,12 @@ func (c *Context) Text(text string) {
 					for p < len(text) && text[p] != ' ' && text[p] != '\n' {
 						p++
 					}
-					w += textWidth(text[word:p])
+					w += c.textWidth(text[word:p])
 					if w > r.Dx() && endIdx != startIdx {
 						break
 					}
 					if p < len(text) {
-						w += textWidth(string(text[p]))
+						w += c.textWidth(string(text[p]))
 					}
 					endIdx = p
 					p++
@@ -150,7 +161,7 @@ func (c *Context) buttonEx(label string, opt option) Response {
 	return c.Control(id, opt, func(r image.Rectangle) Response {
 		var res Response
 		// handle click
-		if c.mousePressed == mouseLeft && c.focus == id {
+		if (c.mousePressed == mouseLeft && c.focus == id) || c.navActivated(id) {
 			res |= ResponseSubmit
 		}
 		// draw
@@ -169,14 +180,14 @@ func (c *Context) Checkbox(label string, state *bool) Response {
 		box := image.Rect(r.Min.X, r.Min.Y, r.Min.X+r.Dy(), r.Max.Y)
 		c.updateControl(id, r, 0)
 		// handle click
-		if c.mousePressed == mouseLeft && c.focus == id {
+		if (c.mousePressed == mouseLeft && c.focus == id) || c.navActivated(id) {
 			res |= ResponseChange
 			*state = !*state
 		}
 		// draw
 		c.drawControlFrame(id, box, ColorBase, 0)
 		if *state {
-			c.drawIcon(iconCheck, box, c.style.colors[ColorText])
+			c.drawIcon(IconCheck, box, c.style.colors[ColorText])
 		}
 		r = image.Rect(r.Min.X+box.Dx(), r.Min.Y, r.Max.X, r.Max.Y)
 		c.drawControlText(label, r, ColorText, 0)
@@ -184,33 +195,32 @@ func (c *Context) Checkbox(label string, state *bool) Response {
 	})
 }
 
-func (c *Context) textField(id ID) *textinput.Field {
-	if id == 0 {
-		return nil
-	}
-	if _, ok := c.textFields[id]; !ok {
-		if c.textFields == nil {
-			c.textFields = make(map[ID]*textinput.Field)
-		}
-		c.textFields[id] = &textinput.Field{}
-	}
-	return c.textFields[id]
-}
-
 func (c *Context) textBoxRaw(buf *string, id ID, opt option) Response {
+	focused := c.focus == id
 	return c.Control(id, opt|optionHoldFocus, func(r image.Rectangle) Response {
 		var res Response
 
+		f := c.textField(id)
+		textx := r.Min.X + c.style.padding - f.scrollX
 		if c.focus == id {
 			// handle text input
-			f := c.textField(id)
-			f.Focus()
-			x := r.Min.X + c.style.padding + textWidth(*buf)
-			y := r.Min.Y + lineHeight()
-			handled, err := f.HandleInput(x, y)
-			if err != nil {
-				fmt.Fprintln(os.Stderr, err)
-				return 0
+			var handled bool
+			if c.usesIME() {
+				f.Focus()
+				x := textx + c.textWidth(f.Text()[:f.caret()])
+				y := r.Min.Y + c.lineHeight()
+				var err error
+				handled, err = f.HandleInput(x, y)
+				if err != nil {
+					fmt.Fprintln(os.Stderr, err)
+					return 0
+				}
+			} else if len(c.inputChars) > 0 && !c.isShortcutDown() {
+				text := f.Text()
+				start, end := f.Selection()
+				text = text[:start] + string(c.inputChars) + text[end:]
+				start += len(string(c.inputChars))
+				f.SetTextAndSelection(text, start, start)
 			}
 			if *buf != f.TextForRendering() {
 				*buf = f.TextForRendering()
@@ -218,14 +228,42 @@ func (c *Context) textBoxRaw(buf *string, id ID, opt option) Response {
 			}
 
 			if !handled {
-				// handle backspace
-				if (c.keyPressed&keyBackspace) != 0 && len(*buf) > 0 {
-					_, size := utf8.DecodeLastRuneInString(*buf)
-					*buf = (*buf)[:len(*buf)-size]
-					f.SetTextAndSelection(*buf, len(*buf), len(*buf))
+				// handle the clipboard shortcuts
+				if c.handleClipboard(f) {
+					*buf = f.Text()
 					res |= ResponseChange
 				}
 
+				// handle the caret movement
+				c.handleCaretKeys(f)
+
+				// handle the mouse: click to place the caret, drag or shift-click to select
+				if c.mousePressed == mouseLeft && c.mouseOver(r) {
+					f.setCaret(c.textIndexAt(f.Text(), c.mousePos.X-textx), focused && (c.keyDown&keyShift) != 0)
+				} else if c.mousePressed == 0 && c.mouseDown == mouseLeft && focused {
+					f.setCaret(c.textIndexAt(f.Text(), c.mousePos.X-textx), true)
+				}
+
+				// handle backspace and delete
+				if (c.keyPressed & (keyBackspace | keyDelete)) != 0 {
+					text := f.Text()
+					start, end := f.Selection()
+					if start == end {
+						if (c.keyPressed&keyBackspace) != 0 && start > 0 {
+							_, size := utf8.DecodeLastRuneInString(text[:start])
+							start -= size
+						} else if (c.keyPressed&keyDelete) != 0 && end < len(text) {
+							_, size := utf8.DecodeRuneInString(text[end:])
+							end += size
+						}
+					}
+					if start != end {
+						*buf = text[:start] + text[end:]
+						f.SetTextAndSelection(*buf, start, start)
+						res |= ResponseChange
+					}
+				}
+
 				// handle return
 				if (c.keyPressed & keyReturn) != 0 {
 					c.SetFocus(0)
@@ -234,24 +272,37 @@ func (c *Context) textBoxRaw(buf *string, id ID, opt option) Response {
 				}
 			}
 		} else {
-			f := c.textField(id)
 			if *buf != f.TextForRendering() {
 				f.SetTextAndSelection(*buf, len(*buf), len(*buf))
 			}
+			f.scrollX = 0
 		}
 
 		// draw
 		c.drawControlFrame(id, r, ColorBase, opt)
 		if c.focus == id {
 			color := c.style.colors[ColorText]
-			textw := textWidth(*buf)
-			texth := lineHeight()
-			ofx := r.Dx() - c.style.padding - textw - 1
-			textx := r.Min.X + min(ofx, c.style.padding)
+			start, end := f.Selection()
+			caret := f.caret()
+			if cs, ce, ok := f.CompositionSelection(); ok {
+				// the composition text is rendered at the selection.
+				start, end = start+cs, start+ce
+				caret = end
+			}
+			start, end, caret = min(start, len(*buf)), min(end, len(*buf)), min(caret, len(*buf))
+			caretx := c.textWidth((*buf)[:caret])
+			f.scrollToCaret(caretx, c.textWidth(*buf), r.Dx()-c.style.padding*2)
+			textx = r.Min.X + c.style.padding - f.scrollX
+			texth := c.lineHeight()
 			texty := r.Min.Y + (r.Dy()-texth)/2
 			c.pushClipRect(r)
+			if start != end {
+				x0 := textx + c.textWidth((*buf)[:start])
+				x1 := textx + c.textWidth((*buf)[:end])
+				c.drawRect(image.Rect(x0, texty, x1, texty+texth), c.style.colors[ColorSelection])
+			}
 			c.drawText(*buf, image.Pt(textx, texty), color)
-			c.drawRect(image.Rect(textx+textw, texty, textx+textw+1, texty+texth), color)
+			c.drawRect(image.Rect(textx+caretx, texty, textx+caretx+1, texty+texth), color)
 			c.popClipRect()
 		} else {
 			c.drawControlText(*buf, r, ColorText, opt)
@@ -287,7 +338,7 @@ func (c *Context) textBoxEx(buf *string, opt option) Response {
 }
 
 func formatNumber(v float64, digits int) string {
-	return fmt.Sprintf("%."+strconv.Itoa(digits)+"f", v)
+	return strconv.FormatFloat(v, 'f', digits, 64)
 }
 
 func (c *Context) sliderEx(value *float64, low, high, step float64, digits int, opt option) Response {
@@ -310,6 +361,13 @@ func (c *Context) sliderEx(value *float64, low, high, step float64, digits int,
 				v = math.Round(v/step) * step
 			}
 		}
+		if d := c.navStep(id); d != 0 {
+			if step != 0 {
+				v += float64(d) * step
+			} else {
+				v += float64(d) * (high - low) / 100
+			}
+		}
 		// clamp and store value, update res
 		*value = clampF(v, low, high)
 		v = *value
@@ -348,6 +406,9 @@ func (c *Context) numberEx(value *float64, step float64, digits int, opt option)
 		if c.focus == id && c.mouseDown == mouseLeft {
 			*value += float64(c.mouseDelta.X) * step
 		}
+		if d := c.navStep(id); d != 0 {
+			*value += float64(d) * step
+		}
 		// set flag if value changed
 		if *value != last {
 			res |= ResponseChange
@@ -378,7 +439,7 @@ func (c *Context) header(label string, istreenode bool, opt option) Response {
 
 	return c.Control(id, 0, func(r image.Rectangle) Response {
 		// handle click (TODO (port): check if this is correct)
-		clicked := c.mousePressed == mouseLeft && c.focus == id
+		clicked := (c.mousePressed == mouseLeft && c.focus == id) || c.navActivated(id)
 		v1, v2 := 0, 0
 		if active {
 			v1 = 1
@@ -407,11 +468,9 @@ func (c *Context) header(label string, istreenode bool, opt option) Response {
 		} else {
 			c.drawControlFrame(id, r, ColorButton, 0)
 		}
-		var icon icon
+		icon := IconCollapsed
 		if expanded {
-			icon = iconExpanded
-		} else {
-			icon = iconCollapsed
+			icon = IconExpanded
 		}
 		c.drawIcon(
 			icon,
@@ -458,7 +517,9 @@ func (c *Context) scrollbarVertical(cnt *container, b image.Rectangle, cs image.
 		base.Max.X = base.Min.X + c.style.scrollbarSize
 
 		// handle input
-		c.updateControl(id, base, 0)
+		hit := base
+		hit.Min.X -= c.hitExtent()
+		c.updateControl(id, hit, 0)
 		if c.focus == id && c.mouseDown == mouseLeft {
 			cnt.layout.Scroll.Y += c.mouseDelta.Y * cs.Y / base.Dy()
 		}
@@ -494,7 +555,9 @@ func (c *Context) scrollbarHorizontal(cnt *container, b image.Rectangle, cs imag
 		base.Max.Y = base.Min.Y + c.style.scrollbarSize
 
 		// handle input
-		c.updateControl(id, base, 0)
+		hit := base
+		hit.Min.Y -= c.hitExtent()
+		c.updateControl(id, hit, 0)
 		if c.focus == id && c.mouseDown == mouseLeft {
 			cnt.layout.Scroll.X += c.mouseDelta.X * cs.X / base.Dx()
 		}
@@ -556,7 +619,7 @@ func (c *Context) pushContainerBody(cnt *container, body image.Rectangle, opt op
 	cnt.layout.Body = body
 }
 
-func (c *Context) window(title string, rect image.Rectangle, opt option, f func(res Response, layout Layout)) {
+func (c *Context) window(title string, rect image.Rectangle, opacity float64, opt option, f func(res Response, layout Layout)) {
 	id := c.id([]byte(title))
 
 	cnt := c.container(id, opt)
@@ -572,6 +635,13 @@ func (c *Context) window(title string, rect image.Rectangle, opt option, f func(
 		cnt.layout.Rect = rect
 	}
 
+	// apply the opacity to all the commands of this window. root containers can be nested, so the alpha is restored.
+	alpha := c.alpha
+	c.alpha = c.updateWindowAlpha(cnt, opacity, opt)
+	defer func() {
+		c.alpha = alpha
+	}()
+
 	c.containerStack = append(c.containerStack, cnt)
 	defer c.popContainer()
 
@@ -583,7 +653,7 @@ func (c *Context) window(title string, rect image.Rectangle, opt option, f func(
 		// on initing these are done in End
 		cnt := c.currentContainer()
 		cnt.tailIdx = c.pushJump(-1)
-		c.commandList[cnt.headIdx].jump.dstIdx = len(c.commandList) //- 1
+		c.commandList[cnt.headIdx].dstIdx = len(c.commandList) //- 1
 	}()
 
 	// set as hover root if the mouse is overlapping this container and it has a
@@ -603,13 +673,14 @@ func (c *Context) window(title string, rect image.Rectangle, opt option, f func(
 
 	// draw frame
 	if (^opt & optionNoFrame) != 0 {
+		c.drawShadow(rect, c.style.cornerRadius, c.style.shadowSize, c.style.colors[ColorShadow])
 		c.drawFrame(rect, ColorWindowBG)
 	}
 
 	// do title bar
 	if (^opt & optionNoTitle) != 0 {
 		tr := rect
-		tr.Max.Y = tr.Min.Y + c.style.titleHeight
+		tr.Max.Y = tr.Min.Y + c.style.titleHeight + c.extraHeight()
 		c.drawFrame(tr, ColorTitleBG)
 
 		// do title text
@@ -628,19 +699,27 @@ func (c *Context) window(title string, rect image.Rectangle, opt option, f func(
 			id := c.id([]byte("!close"))
 			r := image.Rect(tr.Max.X-tr.Dy(), tr.Min.Y, tr.Max.X, tr.Max.Y)
 			tr.Max.X -= r.Dx()
-			c.drawIcon(iconClose, r, c.style.colors[ColorTitleText])
+			c.drawIcon(IconClose, r, c.style.colors[ColorTitleText])
 			c.updateControl(id, r, opt)
 			if c.mousePressed == mouseLeft && id == c.focus {
 				cnt.open = false
 			}
 		}
+
+		// do opacity control
+		if (opt & optionOpacityControl) != 0 {
+			pad := tr.Dy() / 3
+			r := image.Rect(tr.Max.X-tr.Dy()*2, tr.Min.Y+pad, tr.Max.X-pad, tr.Max.Y-pad)
+			tr.Max.X = r.Min.X
+			c.opacityControl(cnt, r, opt)
+		}
 	}
 
 	c.pushContainerBody(cnt, body, opt)
 
 	// do `resize` handle
 	if (^opt & optionNoResize) != 0 {
-		sz := c.style.titleHeight
+		sz := c.style.titleHeight + c.hitExtent()
 		id := c.id([]byte("!resize"))
 		r := image.Rect(rect.Max.X-sz, rect.Max.Y-sz, rect.Max.X, rect.Max.Y)
 		c.updateControl(id, r, opt)
@@ -681,7 +760,52 @@ func (c *Context) OpenPopup(name string) {
 
 func (c *Context) Popup(name string, f func(res Response, layout Layout)) {
 	opt := optionPopup | optionAutoSize | optionNoResize | optionNoScroll | optionNoTitle | optionClosed
-	c.window(name, image.Rectangle{}, opt, f)
+	c.window(name, image.Rectangle{}, 0, opt, f)
+}
+
+// dropdownOpen reports whether the popup of the dropdown control in the current ID scope is open.
+func (c *Context) dropdownOpen() bool {
+	popup := c.container(c.id([]byte("!popup")), optionClosed)
+	return popup != nil && popup.open
+}
+
+// dropdownPopup does the popup under box of the dropdown control in the current ID scope, e.g. a combo box.
+//
+// If toggled is true, the popup is opened or closed. size is the size of the popup,
+// and the popup is at least as wide as box. f does the content of the popup while it is open.
+// Escape closes the popup.
+func (c *Context) dropdownPopup(box image.Rectangle, toggled bool, size image.Point, f func()) {
+	popupID := c.id([]byte("!popup"))
+	popup := c.container(popupID, optionClosed)
+	open := popup != nil && popup.open
+	if toggled {
+		if open {
+			popup.open = false
+			open = false
+		} else {
+			popup = c.container(popupID, 0)
+			popup.open = true
+			// set as hover root so that the popup isn't closed by this click.
+			c.nextHoverRoot = popup
+			c.hoverRoot = popup
+			c.bringToFront(popup)
+			open = true
+		}
+	}
+	if !open {
+		return
+	}
+
+	// align the popup under the box every frame so that it follows the window.
+	popup.layout.Rect = image.Rect(box.Min.X, box.Max.Y, box.Min.X+max(box.Dx(), size.X), box.Max.Y+size.Y)
+
+	opt := optionPopup | optionNoResize | optionNoTitle | optionClosed
+	c.window("!popup", image.Rectangle{}, 0, opt, func(Response, Layout) {
+		if (c.keyPressed & keyEscape) != 0 {
+			popup.open = false
+		}
+		f()
+	})
 }
 
 func (c *Context) panel(name string, opt option, f func(layout Layout)) {
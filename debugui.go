@@ -3,16 +3,69 @@
 
 package debugui
 
-import "github.com/hajimehoshi/ebiten/v2"
+import (
+	"io"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
 
 type DebugUI struct {
 	ctx *Context
 }
 
+// Options represents options for NewWithOptions.
+type Options struct {
+	// InputSource is the source of the input.
+	// If InputSource is nil, the input is read from Ebitengine.
+	InputSource InputSource
+
+	// GamepadMode is the mode how a gamepad operates DebugUI.
+	// A gamepad is used only when the input source implements GamepadInputSource.
+	// The default input source uses the first gamepad in the standard layout.
+	//
+	// The default (zero) value is GamepadModeNone.
+	GamepadMode GamepadMode
+
+	// Clipboard is the clipboard that TextBox copies text to and pastes text from.
+	// If Clipboard is nil, a MemoryClipboard is used.
+	Clipboard Clipboard
+
+	// NoDeviceScale makes DebugUI use 1 instead of the device scale factor of the monitor
+	// when the scale is not set by SetScale.
+	// This is useful to use DebugUI without a window, e.g. in tests.
+	NoDeviceScale bool
+}
+
 func New() *DebugUI {
+	return NewWithOptions(nil)
+}
+
+// NewWithOptions creates a new DebugUI with the given options.
+// options can be nil, and then the default options are used.
+func NewWithOptions(options *Options) *DebugUI {
+	if options == nil {
+		options = &Options{}
+	}
+	var input InputSource = &ebitenInputSource{}
+	if options.InputSource != nil {
+		input = options.InputSource
+	}
+	// the IME is available only when the input is read from Ebitengine.
+	ime := options.InputSource == nil
+	var clipboard Clipboard = &MemoryClipboard{}
+	if options.Clipboard != nil {
+		clipboard = options.Clipboard
+	}
+	style := defaultStyle
 	return &DebugUI{
 		ctx: &Context{
-			style: &defaultStyle,
+			style:         &style,
+			input:         input,
+			clipboard:     clipboard,
+			gamepadMode:   options.GamepadMode,
+			ime:           ime,
+			noDeviceScale: options.NoDeviceScale,
 		},
 	}
 }
@@ -24,3 +77,139 @@ func (d *DebugUI) Update(f func(ctx *Context)) {
 func (d *DebugUI) Draw(screen *ebiten.Image) {
 	d.ctx.draw(screen)
 }
+
+// Render renders the command list of the last Update with the renderer.
+func (d *DebugUI) Render(renderer Renderer) {
+	renderer.Render(d.ctx.commands())
+}
+
+// Commands returns an iterator over the command list of the last Update.
+func (d *DebugUI) Commands() *CommandIterator {
+	return d.ctx.commands()
+}
+
+// DroppedCommands returns the number of the commands dropped in the last Update.
+//
+// The command list holds a limited number of commands in a frame.
+// When it is full, the following drawing commands are dropped and the rest of the UI is not drawn,
+// but the frame is still valid.
+func (d *DebugUI) DroppedCommands() int {
+	return d.ctx.droppedCount
+}
+
+// SetTransform sets the transform from the UI coordinates to the screen coordinates.
+//
+// The transform is applied to drawing, and its inverse is applied to the pointer input.
+// This is useful to show the UI scaled, offset or inside an in-world monitor.
+// If the transform is not invertible, the pointer input is not transformed.
+//
+// The default transform is the identity.
+func (d *DebugUI) SetTransform(geoM ebiten.GeoM) {
+	d.ctx.setTransform(geoM)
+}
+
+// SetScale sets the scale factor from the UI coordinates to the screen pixels.
+//
+// The whole UI including the layout metrics, text and icons is drawn scaled, and the pointer input is mapped to match.
+// The scale is applied before the transform set by SetTransform.
+// Custom drawing functions registered by Context.Draw draw in the UI coordinates, and the drawing is scaled.
+//
+// If scale is 0, the device scale factor of the monitor is used, which is the default.
+// This is useful for a game whose Layout returns the size in device pixels on high-DPI displays.
+func (d *DebugUI) SetScale(scale float64) {
+	d.ctx.scale = scale
+}
+
+// Scale returns the scale factor from the UI coordinates to the screen pixels.
+func (d *DebugUI) Scale() float64 {
+	return d.ctx.uiScale()
+}
+
+// SetFontFace sets the face of the font.
+//
+// face can be any text.Face, e.g. a GoTextFace with CJK coverage or a MultiFace.
+// The heights of the controls and the title bars grow with the line height of the face.
+// face can be nil to use the default face of the font.
+//
+// By default, FontRegular uses the bitmap font, FontMonospace uses Go Mono, FontBold uses Go Bold,
+// and FontLarge uses Go Regular in a larger size.
+// The default faces other than FontRegular fall back to the bitmap font for the glyphs they don't have.
+func (d *DebugUI) SetFontFace(font Font, face text.Face) {
+	d.ctx.setFontFace(font, face)
+}
+
+// SetCornerRadius sets the corner radius of the windows and the controls.
+//
+// The default radius is 0, i.e. the corners are square.
+func (d *DebugUI) SetCornerRadius(radius int) {
+	d.ctx.style.cornerRadius = max(0, radius)
+}
+
+// SetBorderWidth sets the width of the borders of the windows and the controls.
+//
+// The default width is 1.
+func (d *DebugUI) SetBorderWidth(width int) {
+	d.ctx.style.borderWidth = max(0, width)
+}
+
+// SetShadowSize sets the size of the soft shadows of the windows.
+//
+// The default size is 0, i.e. the windows have no shadows.
+func (d *DebugUI) SetShadowSize(size int) {
+	d.ctx.style.shadowSize = max(0, size)
+}
+
+// SetInputRecorder sets the InputRecorder to record the input of every following Update.
+// recorder can be nil to stop recording.
+func (d *DebugUI) SetInputRecorder(recorder *InputRecorder) {
+	d.ctx.recorder = recorder
+}
+
+// SetInputPlayer sets the InputPlayer to replay the input instead of reading the input source.
+// player can be nil to read the input source again.
+func (d *DebugUI) SetInputPlayer(player *InputPlayer) {
+	d.ctx.setInputPlayer(player)
+}
+
+// WantsMouse reports whether the debug UI used the mouse in the last Update,
+// i.e., the cursor is over a window or a control is being dragged.
+//
+// When WantsMouse returns true, the game should skip its own mouse handling.
+func (d *DebugUI) WantsMouse() bool {
+	return d.ctx.wantsMouse()
+}
+
+// WantsKeyboard reports whether the debug UI used the keyboard in the last Update,
+// i.e., a text box is being edited or a control has the keyboard focus.
+//
+// When WantsKeyboard returns true, the game should skip its own keyboard handling.
+func (d *DebugUI) WantsKeyboard() bool {
+	return d.ctx.wantsKeyboard()
+}
+
+// WriteCommandsJSON writes the command list of the last Update as JSON to w.
+//
+// The commands are in drawing order with the jumps between windows resolved:
+//
+//	{"version":1,"commands":[
+//	{"type":"rect","rect":[x0,y0,x1,y1],"color":"#rrggbbaa"},
+//	{"type":"text","pos":[x,y],"color":"#rrggbbaa","text":"...","font":"regular"},
+//	...
+//	]}
+//
+// Each command is on its own line so that snapshots are easy to diff.
+// The types are "clip", "rect", "text", "icon", "draw", "roundedRect", "outline" and "shadow".
+// The colors are premultiplied as Command.Color.
+// The fields not used by a type are omitted.
+func (d *DebugUI) WriteCommandsJSON(w io.Writer) error {
+	return d.ctx.writeJSON(w)
+}
+
+// WriteCommandsSVG writes the command list of the last Update as an SVG image to w.
+//
+// Rectangles, outlines, text and icons are drawn, and clipping commands become clipped groups.
+// Shadows and custom drawing functions are not drawn.
+// This doesn't need a GPU, so this is useful to review UI changes.
+func (d *DebugUI) WriteCommandsSVG(w io.Writer) error {
+	return d.ctx.writeSVG(w)
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"flag"
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// testInput is an InputSource whose state is set by tests.
+type testInput struct {
+	cursor  image.Point
+	wheel   [2]float64
+	buttons map[ebiten.MouseButton]bool
+	keys    map[ebiten.Key]bool
+	chars   []rune
+}
+
+func (t *testInput) CursorPosition() (x, y int) {
+	return t.cursor.X, t.cursor.Y
+}
+
+func (t *testInput) Wheel() (xoff, yoff float64) {
+	return t.wheel[0], t.wheel[1]
+}
+
+func (t *testInput) IsMouseButtonPressed(button ebiten.MouseButton) bool {
+	return t.buttons[button]
+}
+
+func (t *testInput) IsKeyPressed(key ebiten.Key) bool {
+	return t.keys[key]
+}
+
+func (t *testInput) AppendInputChars(runes []rune) []rune {
+	runes = append(runes, t.chars...)
+	t.chars = t.chars[:0]
+	return runes
+}
+
+func newTestInput() *testInput {
+	return &testInput{
+		buttons: map[ebiten.MouseButton]bool{},
+		keys:    map[ebiten.Key]bool{},
+	}
+}
+
+func newTestUI(input InputSource) *DebugUI {
+	return NewWithOptions(&Options{
+		InputSource:   input,
+		NoDeviceScale: true,
+	})
+}
+
+// press presses and releases the key over two frames.
+func press(d *DebugUI, input *testInput, key ebiten.Key, f func(ctx *Context)) {
+	input.keys[key] = true
+	d.Update(f)
+	input.keys[key] = false
+	d.Update(f)
+}
+
+// click moves the cursor to p, and presses and releases the left mouse button over three frames.
+func click(d *DebugUI, input *testInput, p image.Point, f func(ctx *Context)) {
+	input.cursor = p
+	d.Update(f)
+	input.buttons[ebiten.MouseButtonLeft] = true
+	d.Update(f)
+	input.buttons[ebiten.MouseButtonLeft] = false
+	d.Update(f)
+}
@@ -15,6 +15,9 @@ const (
 	commandText
 	commandIcon
 	commandDraw
+	commandRoundedRect
+	commandOutline
+	commandShadow
 )
 
 const (
@@ -32,16 +35,20 @@ const (
 	ColorBaseFocus
 	ColorScrollBase
 	ColorScrollThumb
-	ColorMax = ColorScrollThumb
+	ColorFocusRing
+	ColorSelection
+	ColorShadow
+	ColorMax = ColorShadow
 )
 
-type icon int
+// Icon represents an icon drawn by the UI.
+type Icon int
 
 const (
-	iconClose icon = 1 + iota
-	iconCheck
-	iconCollapsed
-	iconExpanded
+	IconClose Icon = 1 + iota
+	IconCheck
+	IconCollapsed
+	IconExpanded
 )
 
 type Response int
@@ -68,6 +75,8 @@ const (
 	optionPopup
 	optionClosed
 	optionExpanded
+	optionOpacityControl
+	optionFadeWhenNotHovered
 )
 
 const (
@@ -77,9 +86,45 @@ const (
 )
 
 const (
-	keyShift     = (1 << 0)
-	keyControl   = (1 << 1)
-	keyAlt       = (1 << 2)
-	keyBackspace = (1 << 3)
-	keyReturn    = (1 << 4)
+	navNext       = (1 << 0)
+	navPrev       = (1 << 1)
+	navActivate   = (1 << 2)
+	navCancel     = (1 << 3)
+	navNextWindow = (1 << 4)
+	navPrevWindow = (1 << 5)
+	navLeft       = (1 << 6)
+	navRight      = (1 << 7)
+	navUp         = (1 << 8)
+	navDown       = (1 << 9)
+)
+
+const (
+	keyShift      = (1 << 0)
+	keyControl    = (1 << 1)
+	keyAlt        = (1 << 2)
+	keyBackspace  = (1 << 3)
+	keyReturn     = (1 << 4)
+	keyTab        = (1 << 5)
+	keySpace      = (1 << 6)
+	keyDelete     = (1 << 7)
+	keyArrowLeft  = (1 << 8)
+	keyArrowRight = (1 << 9)
+	keyArrowUp    = (1 << 10)
+	keyArrowDown  = (1 << 11)
+	keyHome       = (1 << 12)
+	keyEnd        = (1 << 13)
+	keyEscape     = (1 << 14)
+	keyPageUp     = (1 << 15)
+	keyPageDown   = (1 << 16)
+	keyMeta       = (1 << 17)
+	keyA          = (1 << 18)
+	keyC          = (1 << 19)
+	keyV          = (1 << 20)
+	keyX          = (1 << 21)
+
+	// keyCount is the number of the key bits.
+	keyCount = 22
+
+	// keyRepeatable is the keys that are pressed repeatedly while they are held.
+	keyRepeatable = keyBackspace | keyDelete | keyTab | keyArrowLeft | keyArrowRight | keyArrowUp | keyArrowDown | keyPageUp | keyPageDown
 )
@@ -4,9 +4,11 @@
 package main
 
 import (
+	"image/color"
 	"log"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	"github.com/ebitengine/debugui"
 )
@@ -17,24 +19,33 @@ type Game struct {
 	logBuf       string
 	logSubmitBuf string
 	logUpdated   bool
-	bg           [3]float64
+	bg           color.RGBA
 	checks       [3]bool
 	num1         float64
 	num2         float64
+	comboIndex   int
+	listSelected map[int]bool
+	tps          *debugui.PlotBuffer
+	fps          *debugui.PlotBuffer
 }
 
 func New() *Game {
 	return &Game{
-		debugUI: debugui.New(),
-		bg:      [3]float64{90, 95, 100},
-		checks:  [3]bool{true, false, true},
+		debugUI:      debugui.New(),
+		bg:           color.RGBA{90, 95, 100, 255},
+		checks:       [3]bool{true, false, true},
+		listSelected: map[int]bool{},
+		tps:          debugui.NewPlotBuffer(240),
+		fps:          debugui.NewPlotBuffer(240),
 	}
 }
 
 func (g *Game) Update() error {
-	if ebiten.IsKeyPressed(ebiten.KeyEscape) {
+	if !g.debugUI.WantsKeyboard() && inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
 		return ebiten.Termination
 	}
+	g.tps.Push(ebiten.ActualTPS())
+	g.fps.Push(ebiten.ActualFPS())
 	g.debugUI.Update(func(ctx *debugui.Context) {
 		g.testWindow(ctx)
 		g.logWindow(ctx)
@@ -47,7 +58,10 @@ func (g *Game) Draw(screen *ebiten.Image) {
 }
 
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
-	return 1280, 960
+	// use the device pixels so that the UI is sharp on high-DPI displays.
+	// DebugUI is scaled by the device scale factor by default.
+	s := ebiten.Monitor().DeviceScaleFactor()
+	return int(float64(outsideWidth) * s), int(float64(outsideHeight) * s)
 }
 
 func main() {
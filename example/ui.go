@@ -6,7 +6,6 @@ package main
 import (
 	"fmt"
 	"image"
-	"image/color"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
@@ -15,6 +14,8 @@ import (
 	"github.com/ebitengine/debugui"
 )
 
+var comboItems = []string{"Apple", "Banana", "Cherry", "Durian", "Elderberry", "Fig", "Grape", "Honeydew", "Kiwi", "Lemon"}
+
 func (g *Game) writeLog(text string) {
 	if len(g.logBuf) > 0 {
 		g.logBuf += "\n"
@@ -55,6 +56,16 @@ func (g *Game) testWindow(ctx *debugui.Context) {
 				ctx.Button("Hello")
 				ctx.Button("World")
 			})
+			ctx.SetLayoutRow([]int{100, -1}, 0)
+			ctx.Label("Test combo box:")
+			if ctx.ComboBox("", &g.comboIndex, comboItems)&debugui.ResponseChange != 0 {
+				g.writeLog(fmt.Sprintf("Selected %s", comboItems[g.comboIndex]))
+			}
+			ctx.SetLayoutRow([]int{100, -1}, 80)
+			ctx.Label("Test list box:")
+			if ctx.MultiSelectListBox("List Box", comboItems, g.listSelected)&debugui.ResponseChange != 0 {
+				g.writeLog(fmt.Sprintf("Selected %d items", len(g.listSelected)))
+			}
 		}
 
 		// tree
@@ -102,20 +113,17 @@ func (g *Game) testWindow(ctx *debugui.Context) {
 				"ipsum, eu varius magna felis a nulla.")
 		}
 
-		// background color sliders
+		// background color
 		if ctx.Header("Background Color", true) != 0 {
-			ctx.SetLayoutRow([]int{-78, -1}, 74)
-			// sliders
-			ctx.LayoutColumn(func() {
-				ctx.SetLayoutRow([]int{46, -1}, 0)
-				ctx.Label("Red:")
-				ctx.Slider(&g.bg[0], 0, 255, 1, 0)
-				ctx.Label("Green:")
-				ctx.Slider(&g.bg[1], 0, 255, 1, 0)
-				ctx.Label("Blue:")
-				ctx.Slider(&g.bg[2], 0, 255, 1, 0)
-			})
+			ctx.SetLayoutRow([]int{-1}, 0)
+			ctx.ColorPicker(&g.bg)
+			ctx.SetLayoutRow([]int{100, -1}, 0)
+			ctx.Label("Test color edit:")
+			if ctx.ColorEdit("", &g.bg)&debugui.ResponseChange != 0 {
+				g.writeLog(fmt.Sprintf("Changed color to %v", g.bg))
+			}
 			// color preview
+			ctx.SetLayoutRow([]int{-1}, 40)
 			ctx.Control(0, 0, func(r image.Rectangle) debugui.Response {
 				ctx.Draw(func(screen *ebiten.Image) {
 					vector.DrawFilledRect(
@@ -124,9 +132,9 @@ func (g *Game) testWindow(ctx *debugui.Context) {
 						float32(r.Min.Y),
 						float32(r.Dx()),
 						float32(r.Dy()),
-						color.RGBA{byte(g.bg[0]), byte(g.bg[1]), byte(g.bg[2]), 255},
+						g.bg,
 						false)
-					txt := fmt.Sprintf("#%02X%02X%02X", int(g.bg[0]), int(g.bg[1]), int(g.bg[2]))
+					txt := fmt.Sprintf("#%02X%02X%02X", g.bg.R, g.bg.G, g.bg.B)
 					op := &text.DrawOptions{}
 					op.GeoM.Translate(float64((r.Min.X+r.Max.X)/2), float64((r.Min.Y+r.Max.Y)/2))
 					op.PrimaryAlign = text.AlignCenter
@@ -143,16 +151,39 @@ func (g *Game) testWindow(ctx *debugui.Context) {
 			ctx.Number(&g.num1, 0.1, 2)
 			ctx.Slider(&g.num2, 0, 10, 0.1, 2)
 		}
+
+		// plot
+		if ctx.Header("Plot", true) != 0 {
+			ctx.SetLayoutRow([]int{-1}, 0)
+			ctx.PlotLines("TPS", g.tps.Values(), &debugui.PlotOptions{
+				Min: 0,
+				Max: 70,
+				Series: []debugui.PlotSeries{
+					{Label: "FPS", Values: g.fps.Values()},
+				},
+			})
+			ctx.Histogram("TPS Histogram", g.tps.Values(), 8, nil)
+			ctx.BarChart("Draw Calls", []string{"BG", "World", "UI"}, []float64{4, 12, 7}, &debugui.BarChartOptions{
+				Horizontal: true,
+				Series: []debugui.BarSeries{
+					{Label: "Batched", Values: []float64{2, 30, 5}},
+				},
+			})
+		}
 	})
 }
 
 func (g *Game) logWindow(ctx *debugui.Context) {
-	ctx.Window("Log Window", image.Rect(350, 40, 650, 490), func(res debugui.Response, layout debugui.Layout) {
+	ctx.WindowWithOptions("Log Window", image.Rect(350, 40, 650, 490), &debugui.WindowOptions{
+		OpacityControl: true,
+	}, func(res debugui.Response, layout debugui.Layout) {
 		// output text panel
 		ctx.SetLayoutRow([]int{-1}, -25)
 		ctx.Panel("Log Output", func(layout debugui.Layout) {
 			ctx.SetLayoutRow([]int{-1}, -1)
-			ctx.Text(g.logBuf)
+			ctx.WithFont(debugui.FontMonospace, func() {
+				ctx.Text(g.logBuf)
+			})
 			if g.logUpdated {
 				ctx.SetScroll(image.Pt(layout.Scroll.X, layout.ContentSize.Y))
 				g.logUpdated = false
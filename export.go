@@ -0,0 +1,231 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// commandListJSONVersion is the version of the JSON schema of the exported command list.
+// This must be increased when the schema is changed incompatibly.
+const commandListJSONVersion = 1
+
+// jsonCommand is a command in the JSON schema of the exported command list.
+// The fields are in the order of the JSON output.
+type jsonCommand struct {
+	Type   string  `json:"type"`
+	Rect   *[4]int `json:"rect,omitempty"`
+	Pos    *[2]int `json:"pos,omitempty"`
+	Color  string  `json:"color,omitempty"`
+	Text   *string `json:"text,omitempty"`
+	Font   string  `json:"font,omitempty"`
+	Icon   string  `json:"icon,omitempty"`
+	Radius int     `json:"radius,omitempty"`
+	Width  int     `json:"width,omitempty"`
+}
+
+func commandTypeName(typ CommandType) string {
+	switch typ {
+	case CommandClip:
+		return "clip"
+	case CommandRect:
+		return "rect"
+	case CommandText:
+		return "text"
+	case CommandIcon:
+		return "icon"
+	case CommandDraw:
+		return "draw"
+	case CommandRoundedRect:
+		return "roundedRect"
+	case CommandOutline:
+		return "outline"
+	case CommandShadow:
+		return "shadow"
+	}
+	return ""
+}
+
+func hexColor(clr color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x%02x", clr.R, clr.G, clr.B, clr.A)
+}
+
+func newJSONCommand(cmd Command) jsonCommand {
+	j := jsonCommand{
+		Type: commandTypeName(cmd.Type()),
+	}
+	rect := func() *[4]int {
+		r := cmd.Rect()
+		return &[4]int{r.Min.X, r.Min.Y, r.Max.X, r.Max.Y}
+	}
+	switch cmd.Type() {
+	case CommandClip:
+		j.Rect = rect()
+	case CommandRect:
+		j.Rect = rect()
+		j.Color = hexColor(cmd.Color())
+	case CommandText:
+		p := cmd.Pos()
+		str := cmd.Text()
+		j.Pos = &[2]int{p.X, p.Y}
+		j.Color = hexColor(cmd.Color())
+		j.Text = &str
+		j.Font = cmd.cmd.font.name()
+	case CommandIcon:
+		j.Rect = rect()
+		j.Color = hexColor(cmd.Color())
+		j.Icon = cmd.Icon().name()
+	case CommandDraw:
+		j.Color = hexColor(cmd.Color())
+	case CommandRoundedRect, CommandOutline, CommandShadow:
+		j.Rect = rect()
+		j.Color = hexColor(cmd.Color())
+		j.Radius = cmd.Radius()
+		j.Width = cmd.Width()
+	}
+	return j
+}
+
+// writeJSON writes the command list in the JSON schema.
+// Every command is on its own line so that the output is easy to diff.
+func (c *Context) writeJSON(w io.Writer) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "{\"version\":%d,\"commands\":[", commandListJSONVersion)
+	it := c.commands()
+	first := true
+	for it.Next() {
+		b, err := json.Marshal(newJSONCommand(it.Command()))
+		if err != nil {
+			return err
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		buf.WriteString("\n")
+		buf.Write(b)
+	}
+	buf.WriteString("\n]}\n")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// svgPaint returns the attributes of the fill or the stroke of the premultiplied color.
+// A channel greater than the alpha is invalid for a premultiplied color, and is clamped to the alpha.
+func svgPaint(attr string, clr color.RGBA) string {
+	if clr.A == 0 {
+		return attr + `="none"`
+	}
+	r := uint32(min(clr.R, clr.A)) * 0xff / uint32(clr.A)
+	g := uint32(min(clr.G, clr.A)) * 0xff / uint32(clr.A)
+	b := uint32(min(clr.B, clr.A)) * 0xff / uint32(clr.A)
+	s := fmt.Sprintf(`%s="#%02x%02x%02x"`, attr, r, g, b)
+	if clr.A != 0xff {
+		s += fmt.Sprintf(` %s-opacity="%s"`, attr, strconv.FormatFloat(float64(clr.A)/0xff, 'g', 3, 64))
+	}
+	return s
+}
+
+// svgFontSize returns the font size of the face in pixels.
+func svgFontSize(face text.Face) float64 {
+	if f, ok := face.(*text.GoTextFace); ok {
+		return f.Size
+	}
+	return face.Metrics().HAscent
+}
+
+// writeSVG writes the command list as an SVG image of the bounds of the windows.
+func (c *Context) writeSVG(w io.Writer) error {
+	var bounds image.Rectangle
+	for _, cnt := range c.rootList {
+		bounds = bounds.Union(c.windowBounds(cnt))
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="%d %d %d %d">`+"\n",
+		bounds.Dx(), bounds.Dy(), bounds.Min.X, bounds.Min.Y, bounds.Dx(), bounds.Dy())
+
+	// the icons are used as masks tinted with the colors.
+	buf.WriteString("<defs>\n")
+	for _, icon := range []Icon{IconClose, IconCheck, IconCollapsed, IconExpanded} {
+		fmt.Fprintf(&buf, `<mask id="icon-%s" maskContentUnits="objectBoundingBox"><image width="1" height="1" preserveAspectRatio="none" href="data:image/png;base64,%s"/></mask>`+"\n",
+			icon.name(), base64.StdEncoding.EncodeToString(icon.png()))
+	}
+	buf.WriteString("</defs>\n")
+
+	var clipID int
+	var inGroup bool
+	it := c.commands()
+	for it.Next() {
+		cmd := it.Command()
+		r := cmd.Rect()
+		switch cmd.Type() {
+		case CommandClip:
+			if inGroup {
+				buf.WriteString("</g>\n")
+				inGroup = false
+			}
+			if r == unclippedRect {
+				continue
+			}
+			clipID++
+			fmt.Fprintf(&buf, `<clipPath id="clip%d"><rect x="%d" y="%d" width="%d" height="%d"/></clipPath>`+"\n",
+				clipID, r.Min.X, r.Min.Y, r.Dx(), r.Dy())
+			fmt.Fprintf(&buf, `<g clip-path="url(#clip%d)">`+"\n", clipID)
+			inGroup = true
+		case CommandRect, CommandRoundedRect:
+			fmt.Fprintf(&buf, `<rect x="%d" y="%d" width="%d" height="%d"`, r.Min.X, r.Min.Y, r.Dx(), r.Dy())
+			if cmd.Radius() > 0 {
+				fmt.Fprintf(&buf, ` rx="%d"`, cmd.Radius())
+			}
+			fmt.Fprintf(&buf, " %s/>\n", svgPaint("fill", cmd.Color()))
+		case CommandOutline:
+			// the stroke is centered on the rectangle, so the rectangle is inset by the half of the width.
+			wd := float64(cmd.Width())
+			fmt.Fprintf(&buf, `<rect x="%g" y="%g" width="%g" height="%g"`,
+				float64(r.Min.X)+wd/2, float64(r.Min.Y)+wd/2, float64(r.Dx())-wd, float64(r.Dy())-wd)
+			if cmd.Radius() > 0 {
+				fmt.Fprintf(&buf, ` rx="%g"`, max(float64(cmd.Radius())-wd/2, 0))
+			}
+			fmt.Fprintf(&buf, ` fill="none" stroke-width="%d" %s/>`+"\n", cmd.Width(), svgPaint("stroke", cmd.Color()))
+		case CommandText:
+			p := cmd.Pos()
+			face := cmd.Face()
+			fmt.Fprintf(&buf, `<text x="%d" y="%g" font-family="monospace" font-size="%g" xml:space="preserve" %s>`,
+				p.X, float64(p.Y)+face.Metrics().HAscent, svgFontSize(face), svgPaint("fill", cmd.Color()))
+			if err := xml.EscapeText(&buf, []byte(cmd.Text())); err != nil {
+				return err
+			}
+			buf.WriteString("</text>\n")
+		case CommandIcon:
+			name := cmd.Icon().name()
+			img := cmd.Icon().Image()
+			if name == "" || img == nil {
+				continue
+			}
+			b := img.Bounds()
+			x := r.Min.X + (r.Dx()-b.Dx())/2
+			y := r.Min.Y + (r.Dy()-b.Dy())/2
+			fmt.Fprintf(&buf, `<rect x="%d" y="%d" width="%d" height="%d" mask="url(#icon-%s)" %s/>`+"\n",
+				x, y, b.Dx(), b.Dy(), name, svgPaint("fill", cmd.Color()))
+		}
+	}
+	if inGroup {
+		buf.WriteString("</g>\n")
+	}
+	buf.WriteString("</svg>\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"bytes"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteCommandsGolden(t *testing.T) {
+	d := newTestUI(newTestInput())
+	d.Update(goldenFrame)
+
+	testCases := []struct {
+		name  string
+		write func(d *DebugUI, buf *bytes.Buffer) error
+	}{
+		{
+			name: "commands.json",
+			write: func(d *DebugUI, buf *bytes.Buffer) error {
+				return d.WriteCommandsJSON(buf)
+			},
+		},
+		{
+			name: "commands.svg",
+			write: func(d *DebugUI, buf *bytes.Buffer) error {
+				return d.WriteCommandsSVG(buf)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tc.write(d, &buf); err != nil {
+				t.Fatal(err)
+			}
+			path := filepath.Join("testdata", tc.name)
+			if *update {
+				if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != string(want) {
+				t.Errorf("got:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestSVGPaint(t *testing.T) {
+	testCases := []struct {
+		clr  color.RGBA
+		want string
+	}{
+		{color.RGBA{}, `fill="none"`},
+		{color.RGBA{0x12, 0x34, 0x56, 0xff}, `fill="#123456"`},
+		{color.RGBA{0x40, 0x20, 0x00, 0x80}, `fill="#7f3f00" fill-opacity="0.502"`},
+		// the channels greater than the alpha are clamped.
+		{color.RGBA{0xff, 0x80, 0x00, 0x80}, `fill="#ffff00" fill-opacity="0.502"`},
+	}
+	for _, tc := range testCases {
+		if got := svgPaint("fill", tc.clr); got != tc.want {
+			t.Errorf("svgPaint(%v) = %s, want %s", tc.clr, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/hajimehoshi/bitmapfont/v3"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// Font is the name of a font face that widgets and Text can select with Context.WithFont.
+type Font int
+
+const (
+	// FontRegular is the face used by default.
+	FontRegular Font = iota
+
+	// FontMonospace is the face for code and numbers.
+	FontMonospace
+
+	// FontBold is the face for emphasis.
+	FontBold
+
+	// FontLarge is the face for headings.
+	FontLarge
+
+	fontCount
+)
+
+// name returns the name of the font used in the exported command list.
+func (f Font) name() string {
+	switch f {
+	case FontRegular:
+		return "regular"
+	case FontMonospace:
+		return "monospace"
+	case FontBold:
+		return "bold"
+	case FontLarge:
+		return "large"
+	}
+	return ""
+}
+
+// defaultFace is the bitmap font, which is the default face of FontRegular.
+var defaultFace = text.NewGoXFace(bitmapfont.Face)
+
+// defaultFaces returns the faces used when no face is set for the fonts.
+// The faces other than FontRegular fall back to the bitmap font for the glyphs they don't have, e.g. CJK.
+var defaultFaces = sync.OnceValue(func() [fontCount]text.Face {
+	newFace := func(ttf []byte, size float64) text.Face {
+		src, err := text.NewGoTextFaceSource(bytes.NewReader(ttf))
+		if err != nil {
+			panic(err)
+		}
+		f, err := text.NewMultiFace(&text.GoTextFace{Source: src, Size: size}, defaultFace)
+		if err != nil {
+			panic(err)
+		}
+		return f
+	}
+	return [fontCount]text.Face{
+		FontRegular:   defaultFace,
+		FontMonospace: newFace(gomono.TTF, 12),
+		FontBold:      newFace(gobold.TTF, 12),
+		FontLarge:     newFace(goregular.TTF, 18),
+	}
+})
+
+// defaultLineHeight is the line height of defaultFace, which the style sizes are designed for.
+var defaultLineHeight = faceLineHeight(defaultFace)
+
+// DrawText draws the text with the default face.
+func DrawText(dst *ebiten.Image, str string, op *text.DrawOptions) {
+	text.Draw(dst, str, defaultFace, op)
+}
+
+func faceLineHeight(face text.Face) int {
+	m := face.Metrics()
+	return int(m.HAscent + m.HDescent + m.HLineGap)
+}
+
+// setFontFace sets the face of the font.
+// face can be nil to use the default face of the font.
+func (c *Context) setFontFace(font Font, face text.Face) {
+	if font < 0 || font >= fontCount {
+		return
+	}
+	c.faces[font] = face
+	// the cached windows may have text drawn with the old face.
+	for _, wc := range c.windowCaches {
+		wc.valid = false
+	}
+}
+
+// currentFont returns the font selected by WithFont.
+func (c *Context) currentFont() Font {
+	if len(c.fontStack) == 0 {
+		return FontRegular
+	}
+	return c.fontStack[len(c.fontStack)-1]
+}
+
+// face returns the face of the current font.
+func (c *Context) face() text.Face {
+	font := c.currentFont()
+	if f := c.faces[font]; f != nil {
+		return f
+	}
+	return defaultFaces()[font]
+}
+
+// WithFont calls f with the font selected for the widgets and Text in f.
+func (c *Context) WithFont(font Font, f func()) {
+	c.fontStack = append(c.fontStack, font)
+	defer func() {
+		c.fontStack = c.fontStack[:len(c.fontStack)-1]
+	}()
+	f()
+}
+
+func (c *Context) textWidth(str string) int {
+	return int(text.Advance(str, c.face()))
+}
+
+func (c *Context) lineHeight() int {
+	return faceLineHeight(c.face())
+}
+
+// extraHeight returns how much taller the current face is than the default face.
+// The heights of the controls and the title bar grow by this so that the text fits.
+func (c *Context) extraHeight() int {
+	return max(0, c.lineHeight()-defaultLineHeight)
+}
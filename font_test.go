@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"slices"
+	"testing"
+)
+
+func TestWithFont(t *testing.T) {
+	d := newTestUI(newTestInput())
+	var got []Font
+	d.Update(func(ctx *Context) {
+		got = append(got, ctx.currentFont())
+		ctx.WithFont(FontBold, func() {
+			got = append(got, ctx.currentFont())
+			ctx.WithFont(FontLarge, func() {
+				got = append(got, ctx.currentFont())
+			})
+			got = append(got, ctx.currentFont())
+		})
+		got = append(got, ctx.currentFont())
+	})
+	if want := []Font{FontRegular, FontBold, FontLarge, FontBold, FontRegular}; !slices.Equal(got, want) {
+		t.Errorf("current fonts: got %v, want %v", got, want)
+	}
+}
+
+func TestDefaultFaces(t *testing.T) {
+	faces := defaultFaces()
+	for i := Font(0); i < fontCount; i++ {
+		for j := i + 1; j < fontCount; j++ {
+			if faces[i] == faces[j] {
+				t.Errorf("the default faces of %s and %s are the same", i.name(), j.name())
+			}
+		}
+	}
+}
+
+func TestFontStackNotEmpty(t *testing.T) {
+	d := newTestUI(newTestInput())
+	defer func() {
+		if r := recover(); r != "font stack not empty" {
+			t.Errorf("recover() = %v, want %q", r, "font stack not empty")
+		}
+	}()
+	d.Update(func(ctx *Context) {
+		ctx.fontStack = append(ctx.fontStack, FontBold)
+	})
+}
+
+func TestExtraHeight(t *testing.T) {
+	d := newTestUI(newTestInput())
+	var regular, large int
+	var regularTitle, largeTitle int
+	var regularButton, largeButton int
+	d.Update(func(ctx *Context) {
+		regular = ctx.extraHeight()
+		ctx.Window("Regular", image.Rect(10, 10, 210, 110), func(res Response, layout Layout) {
+			regularTitle = layout.Body.Min.Y - layout.Rect.Min.Y
+			ctx.Button("Button")
+			regularButton = ctx.lastRect.Dy()
+		})
+		ctx.WithFont(FontLarge, func() {
+			large = ctx.extraHeight()
+			ctx.Window("Large", image.Rect(10, 120, 210, 220), func(res Response, layout Layout) {
+				largeTitle = layout.Body.Min.Y - layout.Rect.Min.Y
+				ctx.Button("Button")
+				largeButton = ctx.lastRect.Dy()
+			})
+		})
+	})
+	if regular != 0 {
+		t.Errorf("extraHeight() of FontRegular = %d, want 0", regular)
+	}
+	if large <= 0 {
+		t.Fatalf("extraHeight() of FontLarge = %d, want > 0", large)
+	}
+	if got, want := largeTitle, regularTitle+large; got != want {
+		t.Errorf("title height: got %d, want %d", got, want)
+	}
+	if got, want := largeButton, regularButton+large; got != want {
+		t.Errorf("button height: got %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// GamepadMode represents how a gamepad operates DebugUI.
+type GamepadMode int
+
+const (
+	// GamepadModeNone ignores gamepads.
+	GamepadModeNone GamepadMode = iota
+
+	// GamepadModeNavigation moves the keyboard focus to the nearest control in the direction of the D-pad
+	// in the front-most window.
+	// The bottom face button activates the focused control, the right face button cancels,
+	// and the shoulder buttons switch windows.
+	GamepadModeNavigation
+
+	// GamepadModeVirtualCursor moves a virtual mouse cursor with the left stick.
+	// The bottom and right face buttons act as the left and right mouse buttons,
+	// and the right stick scrolls.
+	GamepadModeVirtualCursor
+)
+
+// GamepadInputSource is an InputSource that also provides a gamepad in the standard layout.
+//
+// The gamepad is used only when the input source implements GamepadInputSource.
+type GamepadInputSource interface {
+	InputSource
+
+	// IsStandardGamepadButtonPressed reports whether the gamepad button is pressed.
+	IsStandardGamepadButtonPressed(button ebiten.StandardGamepadButton) bool
+
+	// StandardGamepadAxisValue returns the value of the gamepad axis in [-1, 1].
+	StandardGamepadAxisValue(axis ebiten.StandardGamepadAxis) float64
+}
+
+const (
+	gamepadDeadZone       = 0.15
+	gamepadCursorSpeed    = 8
+	gamepadScrollSpeed    = 15
+	gamepadCursorHalfSize = 4
+	gamepadCursorBorder   = 1
+)
+
+var gamepadButtons = []ebiten.StandardGamepadButton{
+	ebiten.StandardGamepadButtonLeftTop,
+	ebiten.StandardGamepadButtonLeftBottom,
+	ebiten.StandardGamepadButtonLeftLeft,
+	ebiten.StandardGamepadButtonLeftRight,
+	ebiten.StandardGamepadButtonRightBottom,
+	ebiten.StandardGamepadButtonRightRight,
+	ebiten.StandardGamepadButtonFrontTopLeft,
+	ebiten.StandardGamepadButtonFrontTopRight,
+}
+
+func gamepadButtonToInt(button ebiten.StandardGamepadButton) int {
+	for i, b := range gamepadButtons {
+		if b == button {
+			return 1 << i
+		}
+	}
+	return 0
+}
+
+func gamepadAxis(src GamepadInputSource, axis ebiten.StandardGamepadAxis) float64 {
+	v := src.StandardGamepadAxisValue(axis)
+	if math.Abs(v) < gamepadDeadZone {
+		return 0
+	}
+	return v
+}
+
+// updateGamepad reads the gamepad and returns the buttons that were just pressed.
+func (c *Context) updateGamepad(src GamepadInputSource) int {
+	var down int
+	for _, b := range gamepadButtons {
+		if src.IsStandardGamepadButtonPressed(b) {
+			down |= gamepadButtonToInt(b)
+		}
+	}
+	pressed := down &^ c.gamepadDown
+	c.gamepadDown = down
+	return pressed
+}
+
+// updateGamepadNav translates the gamepad buttons into the keyboard focus navigation.
+func (c *Context) updateGamepadNav(src GamepadInputSource) {
+	pressed := c.updateGamepad(src)
+	if pressed&gamepadButtonToInt(ebiten.StandardGamepadButtonLeftLeft) != 0 {
+		c.navPressed |= navLeft
+	}
+	if pressed&gamepadButtonToInt(ebiten.StandardGamepadButtonLeftRight) != 0 {
+		c.navPressed |= navRight
+	}
+	if pressed&gamepadButtonToInt(ebiten.StandardGamepadButtonLeftTop) != 0 {
+		c.navPressed |= navUp
+	}
+	if pressed&gamepadButtonToInt(ebiten.StandardGamepadButtonLeftBottom) != 0 {
+		c.navPressed |= navDown
+	}
+	if pressed&gamepadButtonToInt(ebiten.StandardGamepadButtonRightBottom) != 0 {
+		c.navPressed |= navActivate
+	}
+	if pressed&gamepadButtonToInt(ebiten.StandardGamepadButtonRightRight) != 0 {
+		c.navPressed |= navCancel
+	}
+	if pressed&gamepadButtonToInt(ebiten.StandardGamepadButtonFrontTopRight) != 0 {
+		c.navPressed |= navNextWindow
+	}
+	if pressed&gamepadButtonToInt(ebiten.StandardGamepadButtonFrontTopLeft) != 0 {
+		c.navPressed |= navPrevWindow
+	}
+}
+
+// updateVirtualCursor moves the virtual cursor with the left stick and returns its position.
+func (c *Context) updateVirtualCursor(src GamepadInputSource, x, y int) (int, int) {
+	c.updateGamepad(src)
+	if !c.virtualCursorInit {
+		c.virtualCursor = [2]float64{float64(x), float64(y)}
+		c.virtualCursorInit = true
+	}
+	c.virtualCursor[0] += gamepadAxis(src, ebiten.StandardGamepadAxisLeftStickHorizontal) * gamepadCursorSpeed
+	c.virtualCursor[1] += gamepadAxis(src, ebiten.StandardGamepadAxisLeftStickVertical) * gamepadCursorSpeed
+	// keep the cursor on the screen. the screen bounds are known after the first Draw.
+	b := image.Rect(0, 0, math.MaxInt32, math.MaxInt32)
+	if !c.screenBounds.Empty() {
+		b = c.screenBounds
+	}
+	c.virtualCursor[0] = clampF(c.virtualCursor[0], float64(b.Min.X), float64(b.Max.X-1))
+	c.virtualCursor[1] = clampF(c.virtualCursor[1], float64(b.Min.Y), float64(b.Max.Y-1))
+	if sx, sy := gamepadAxis(src, ebiten.StandardGamepadAxisRightStickHorizontal), gamepadAxis(src, ebiten.StandardGamepadAxisRightStickVertical); sx != 0 || sy != 0 {
+		c.inputScroll(int(sx*gamepadScrollSpeed), int(sy*gamepadScrollSpeed))
+	}
+	return int(c.virtualCursor[0]), int(c.virtualCursor[1])
+}
+
+// virtualCursorButton returns the gamepad button that acts as the mouse button in the virtual cursor mode.
+func virtualCursorButton(button ebiten.MouseButton) (ebiten.StandardGamepadButton, bool) {
+	switch button {
+	case ebiten.MouseButtonLeft:
+		return ebiten.StandardGamepadButtonRightBottom, true
+	case ebiten.MouseButtonRight:
+		return ebiten.StandardGamepadButtonRightRight, true
+	}
+	return 0, false
+}
+
+// drawVirtualCursor pushes the commands of the virtual cursor on top of everything.
+func (c *Context) drawVirtualCursor() {
+	p := c.mousePos
+	r := image.Rect(p.X-gamepadCursorHalfSize, p.Y-gamepadCursorHalfSize, p.X+gamepadCursorHalfSize+1, p.Y+gamepadCursorHalfSize+1)
+	h := image.Rect(r.Min.X, p.Y, r.Max.X, p.Y+1)
+	v := image.Rect(p.X, r.Min.Y, p.X+1, r.Max.Y)
+	for _, rect := range []image.Rectangle{h.Inset(-gamepadCursorBorder), v.Inset(-gamepadCursorBorder)} {
+		cmd := c.pushCommand(commandRect)
+		cmd.rect = rect
+		cmd.color = c.style.colors[ColorBorder]
+	}
+	for _, rect := range []image.Rectangle{h, v} {
+		cmd := c.pushCommand(commandRect)
+		cmd.rect = rect
+		cmd.color = c.style.colors[ColorTitleText]
+	}
+}
+
+// isVirtualCursorButtonPressed reports whether the gamepad button acting as the mouse button is pressed.
+func (c *Context) isVirtualCursorButtonPressed(src GamepadInputSource, button ebiten.MouseButton) bool {
+	if src == nil || c.gamepadMode != GamepadModeVirtualCursor {
+		return false
+	}
+	b, ok := virtualCursorButton(button)
+	if !ok {
+		return false
+	}
+	return src.IsStandardGamepadButtonPressed(b)
+}
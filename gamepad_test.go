@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// testGamepadInput is a GamepadInputSource whose state is set by tests.
+type testGamepadInput struct {
+	testInput
+	gamepadButtons map[ebiten.StandardGamepadButton]bool
+	axes           map[ebiten.StandardGamepadAxis]float64
+}
+
+func (t *testGamepadInput) IsStandardGamepadButtonPressed(button ebiten.StandardGamepadButton) bool {
+	return t.gamepadButtons[button]
+}
+
+func (t *testGamepadInput) StandardGamepadAxisValue(axis ebiten.StandardGamepadAxis) float64 {
+	return t.axes[axis]
+}
+
+func newTestGamepadInput() *testGamepadInput {
+	return &testGamepadInput{
+		testInput:      *newTestInput(),
+		gamepadButtons: map[ebiten.StandardGamepadButton]bool{},
+		axes:           map[ebiten.StandardGamepadAxis]float64{},
+	}
+}
+
+func TestGamepadSpatialNavigation(t *testing.T) {
+	input := newTestGamepadInput()
+	d := NewWithOptions(&Options{
+		InputSource:   input,
+		GamepadMode:   GamepadModeNavigation,
+		NoDeviceScale: true,
+	})
+	// a 2x2 grid of buttons, and a wide button under them.
+	ids := map[string]ID{}
+	f := func(ctx *Context) {
+		ctx.Window("Window", image.Rect(0, 0, 300, 200), func(res Response, layout Layout) {
+			ctx.SetLayoutRow([]int{100, 100}, 0)
+			for _, label := range []string{"A", "B", "C", "D"} {
+				ctx.Button(label)
+				ids[label] = ctx.LastID
+			}
+			ctx.SetLayoutRow([]int{-1}, 0)
+			ctx.Button("E")
+			ids["E"] = ctx.LastID
+		})
+	}
+	d.Update(f)
+
+	testCases := []struct {
+		button ebiten.StandardGamepadButton
+		want   string
+	}{
+		{ebiten.StandardGamepadButtonLeftBottom, "A"},
+		{ebiten.StandardGamepadButtonLeftRight, "B"},
+		{ebiten.StandardGamepadButtonLeftRight, "B"},
+		{ebiten.StandardGamepadButtonLeftBottom, "D"},
+		{ebiten.StandardGamepadButtonLeftLeft, "C"},
+		{ebiten.StandardGamepadButtonLeftBottom, "E"},
+		// the nearest of the controls above the wide button is the one closer to its center.
+		{ebiten.StandardGamepadButtonLeftTop, "D"},
+		{ebiten.StandardGamepadButtonLeftTop, "B"},
+		{ebiten.StandardGamepadButtonLeftLeft, "A"},
+		{ebiten.StandardGamepadButtonLeftLeft, "A"},
+	}
+	for i, tc := range testCases {
+		input.gamepadButtons[tc.button] = true
+		d.Update(f)
+		input.gamepadButtons[tc.button] = false
+		d.Update(f)
+		if got := d.ctx.navFocus; got != ids[tc.want] {
+			t.Errorf("step %d: navFocus: got %v, want %s (%v)", i, got, tc.want, ids[tc.want])
+		}
+	}
+}
+
+func TestVirtualCursorClamp(t *testing.T) {
+	input := newTestGamepadInput()
+	d := NewWithOptions(&Options{
+		InputSource:   input,
+		GamepadMode:   GamepadModeVirtualCursor,
+		NoDeviceScale: true,
+	})
+	f := func(ctx *Context) {}
+	d.Update(f)
+	d.Draw(ebiten.NewImage(100, 80))
+
+	input.axes[ebiten.StandardGamepadAxisLeftStickHorizontal] = 1
+	input.axes[ebiten.StandardGamepadAxisLeftStickVertical] = 1
+	for i := 0; i < 100; i++ {
+		d.Update(f)
+	}
+	if got, want := d.ctx.mousePos, image.Pt(99, 79); got != want {
+		t.Errorf("cursor: got %v, want %v", got, want)
+	}
+
+	input.axes[ebiten.StandardGamepadAxisLeftStickHorizontal] = -1
+	input.axes[ebiten.StandardGamepadAxisLeftStickVertical] = -1
+	for i := 0; i < 100; i++ {
+		d.Update(f)
+	}
+	if got, want := d.ctx.mousePos, image.Pt(0, 0); got != want {
+		t.Errorf("cursor: got %v, want %v", got, want)
+	}
+}
@@ -4,8 +4,9 @@
 package debugui
 
 import (
+	"cmp"
 	"image"
-	"sort"
+	"slices"
 	"unsafe"
 )
 
@@ -152,9 +153,11 @@ func (c *Context) update(f func(ctx *Context)) {
 }
 
 func (c *Context) begin() {
+	c.updateScale()
 	c.updateInput()
 
 	c.commandList = c.commandList[:0]
+	c.droppedCount = 0
 	c.rootList = c.rootList[:0]
 	c.scrollTarget = nil
 	c.hoverRoot = c.nextHoverRoot
@@ -162,6 +165,10 @@ func (c *Context) begin() {
 	c.mouseDelta.X = c.mousePos.X - c.lastMousePos.X
 	c.mouseDelta.Y = c.mousePos.Y - c.lastMousePos.Y
 	c.tick++
+	c.alpha = 1
+	c.tooltip = c.tooltip[:0]
+	c.tooltipText = c.tooltipText[:0]
+	c.beginNav()
 }
 
 func (c *Context) end() {
@@ -178,19 +185,34 @@ func (c *Context) end() {
 	if len(c.layoutStack) > 0 {
 		panic("layout stack not empty")
 	}
+	if len(c.fontStack) > 0 {
+		panic("font stack not empty")
+	}
 
 	// handle scroll input
 	if c.scrollTarget != nil {
+		if (c.keyPressed & keyPageUp) != 0 {
+			c.scrollDelta.Y -= c.scrollTarget.layout.Body.Dy()
+		}
+		if (c.keyPressed & keyPageDown) != 0 {
+			c.scrollDelta.Y += c.scrollTarget.layout.Body.Dy()
+		}
 		c.scrollTarget.layout.Scroll.X += c.scrollDelta.X
 		c.scrollTarget.layout.Scroll.Y += c.scrollDelta.Y
 	}
 
+	// handle keyboard focus navigation
+	c.endNav()
+
 	// unset focus if focus id was not touched this frame
 	if !c.keepFocus {
 		c.focus = 0
 	}
 	c.keepFocus = false
 
+	// dispose the states of the color pickers that are not updated this frame
+	c.pruneColorPickers()
+
 	// bring hover root to front if mouse was pressed
 	if c.mousePressed != 0 && c.nextHoverRoot != nil &&
 		c.nextHoverRoot.zIndex < c.lastZIndex &&
@@ -205,9 +227,13 @@ func (c *Context) end() {
 	c.lastMousePos = c.mousePos
 
 	// sort root containers by zindex
-	sort.SliceStable(c.rootList, func(i, j int) bool {
-		return c.rootList[i].zIndex < c.rootList[j].zIndex
+	slices.SortStableFunc(c.rootList, func(a, b *container) int {
+		return cmp.Compare(a.zIndex, b.zIndex)
 	})
+	c.frontRoot = nil
+	if len(c.rootList) > 0 {
+		c.frontRoot = c.rootList[len(c.rootList)-1]
+	}
 
 	// set root container jump commands
 	for i := 0; i < len(c.rootList); i++ {
@@ -215,21 +241,28 @@ func (c *Context) end() {
 		// if this is the first container then make the first command jump to it.
 		// otherwise set the previous container's tail to jump to this one
 		if i == 0 {
-			cmd := c.commandList[0]
+			cmd := &c.commandList[0]
 			if cmd.typ != commandJump {
 				panic("expected jump command")
 			}
-			cmd.jump.dstIdx = cnt.headIdx + 1
+			cmd.dstIdx = cnt.headIdx + 1
 			if cnt.headIdx >= len(c.commandList) {
 				panic("invalid head index")
 			}
 		} else {
 			prev := c.rootList[i-1]
-			c.commandList[prev.tailIdx].jump.dstIdx = cnt.headIdx + 1
+			c.commandList[prev.tailIdx].dstIdx = cnt.headIdx + 1
 		}
 		// make the last container's tail jump to the end of command list
 		if i == len(c.rootList)-1 {
-			c.commandList[cnt.tailIdx].jump.dstIdx = len(c.commandList)
+			c.commandList[cnt.tailIdx].dstIdx = len(c.commandList)
 		}
 	}
+
+	// draw the tooltip and the virtual cursor on top of everything
+	c.overlayIdx = len(c.commandList)
+	c.drawTooltip()
+	if _, ok := c.input.(GamepadInputSource); ok && c.gamepadMode == GamepadModeVirtualCursor {
+		c.drawVirtualCursor()
+	}
 }
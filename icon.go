@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"bytes"
+	"embed"
+	"image"
+	"image/png"
+	"sync"
+)
+
+var (
+	//go:embed icon/*.png
+	iconFS   embed.FS
+	iconSrcs = map[Icon]image.Image{}
+	iconSrcM sync.Mutex
+)
+
+// Image returns the image of the icon.
+// The icon is white and is supposed to be tinted with the color of the command.
+// Image returns nil for an unknown icon.
+func (i Icon) Image() image.Image {
+	iconSrcM.Lock()
+	defer iconSrcM.Unlock()
+
+	if img, ok := iconSrcs[i]; ok {
+		return img
+	}
+
+	b := i.png()
+	if b == nil {
+		return nil
+	}
+	img, err := png.Decode(bytes.NewReader(b))
+	if err != nil {
+		panic(err)
+	}
+	iconSrcs[i] = img
+	return img
+}
+
+// name returns the name of the icon, or an empty string for an unknown icon.
+func (i Icon) name() string {
+	switch i {
+	case IconCheck:
+		return "check"
+	case IconClose:
+		return "close"
+	case IconCollapsed:
+		return "collapsed"
+	case IconExpanded:
+		return "expanded"
+	}
+	return ""
+}
+
+// png returns the PNG data of the icon, or nil for an unknown icon.
+func (i Icon) png() []byte {
+	name := i.name()
+	if name == "" {
+		return nil
+	}
+	b, err := iconFS.ReadFile("icon/" + name + ".png")
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
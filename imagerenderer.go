@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	"github.com/hajimehoshi/bitmapfont/v3"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// ImageRenderer is a Renderer that rasterizes the commands into an *image.RGBA in pure Go.
+//
+// ImageRenderer doesn't need a GPU, so it is useful for pixel tests of UIs in CI.
+// Text is drawn with the same font face as the Ebitengine renderer if the face is a GoXFace,
+// and with the bitmap font otherwise.
+// Rounded corners are drawn square, and CommandShadow and CommandDraw commands are ignored.
+type ImageRenderer struct {
+	// Target is the image to rasterize into.
+	Target *image.RGBA
+}
+
+// Render implements Renderer.
+func (r *ImageRenderer) Render(commands *CommandIterator) {
+	target := r.Target
+	for commands.Next() {
+		cmd := commands.Command()
+		switch cmd.Type() {
+		case CommandRect, CommandRoundedRect:
+			// rounded corners are not supported, and the rectangle is filled.
+			draw.Draw(target, cmd.Rect(), image.NewUniform(cmd.Color()), image.Point{}, draw.Over)
+		case CommandOutline:
+			rect, w, src := cmd.Rect(), cmd.Width(), image.NewUniform(cmd.Color())
+			draw.Draw(target, image.Rect(rect.Min.X+w, rect.Min.Y, rect.Max.X-w, rect.Min.Y+w), src, image.Point{}, draw.Over)
+			draw.Draw(target, image.Rect(rect.Min.X+w, rect.Max.Y-w, rect.Max.X-w, rect.Max.Y), src, image.Point{}, draw.Over)
+			draw.Draw(target, image.Rect(rect.Min.X, rect.Min.Y, rect.Min.X+w, rect.Max.Y), src, image.Point{}, draw.Over)
+			draw.Draw(target, image.Rect(rect.Max.X-w, rect.Min.Y, rect.Max.X, rect.Max.Y), src, image.Point{}, draw.Over)
+		case CommandText:
+			face := imageFace(cmd.Face())
+			d := font.Drawer{
+				Dst:  target,
+				Src:  image.NewUniform(cmd.Color()),
+				Face: face,
+				Dot:  fixed.P(cmd.Pos().X, cmd.Pos().Y).Add(fixed.Point26_6{Y: face.Metrics().Ascent}),
+			}
+			d.DrawString(cmd.Text())
+		case CommandIcon:
+			img := cmd.Icon().Image()
+			if img == nil {
+				continue
+			}
+			rect := cmd.Rect()
+			b := img.Bounds()
+			x := rect.Min.X + (rect.Dx()-b.Dx())/2
+			y := rect.Min.Y + (rect.Dy()-b.Dy())/2
+			// the icon is white, so its alpha is used as the mask of the color.
+			draw.DrawMask(target, image.Rect(x, y, x+b.Dx(), y+b.Dy()), image.NewUniform(cmd.Color()), image.Point{}, img, b.Min, draw.Over)
+		case CommandClip:
+			target = r.Target.SubImage(cmd.Rect()).(*image.RGBA)
+		}
+	}
+}
+
+// imageFace returns the font.Face to rasterize the text of face in pure Go.
+// Only a GoXFace can be rasterized, and the bitmap font is used for the other faces.
+func imageFace(face text.Face) font.Face {
+	if f, ok := face.(*text.GoXFace); ok {
+		return f.UnsafeInternal()
+	}
+	return bitmapfont.Face
+}
+
+// RenderToImage rasterizes the command list of the last Update into a new *image.RGBA of the given bounds,
+// filled with the background color.
+func (d *DebugUI) RenderToImage(bounds image.Rectangle, background color.Color) *image.RGBA {
+	img := image.NewRGBA(bounds)
+	if background != nil {
+		draw.Draw(img, bounds, image.NewUniform(background), image.Point{}, draw.Src)
+	}
+	d.Render(&ImageRenderer{Target: img})
+	return img
+}
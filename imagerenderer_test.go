@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// goldenFrame does a fixed UI that has rectangles, clipped text and icons.
+func goldenFrame(ctx *Context) {
+	checked := true
+	ctx.Window("Golden", image.Rect(10, 10, 190, 150), func(res Response, layout Layout) {
+		ctx.SetLayoutRow([]int{60, -1}, 0)
+		ctx.Label("Label")
+		ctx.Button("Button")
+		ctx.Checkbox("Check", &checked)
+		ctx.Label("A label too long to fit in the control")
+		ctx.SetLayoutRow([]int{-1}, 0)
+		ctx.TreeNode("Tree", func(res Response) {})
+	})
+}
+
+func TestImageRendererGolden(t *testing.T) {
+	d := newTestUI(newTestInput())
+	d.Update(goldenFrame)
+	got := d.RenderToImage(image.Rect(0, 0, 200, 160), color.RGBA{0x20, 0x20, 0x20, 0xff})
+
+	path := filepath.Join("testdata", "imagerenderer.png")
+	if *update {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, got); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	want, err := png.Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want.Bounds() != got.Bounds() {
+		t.Fatalf("bounds: got %v, want %v", got.Bounds(), want.Bounds())
+	}
+	b := got.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if g, w := got.RGBAAt(x, y), color.RGBAModel.Convert(want.At(x, y)); g != w {
+				t.Fatalf("pixel at (%d, %d): got %v, want %v", x, y, g, w)
+			}
+		}
+	}
+}
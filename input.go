@@ -5,10 +5,172 @@ package debugui
 
 import (
 	"image"
+	"math"
+	"math/bits"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
+// InputSource is the source of the input that DebugUI reads every frame.
+//
+// The methods report the current state rather than events.
+// DebugUI compares the state with the previous frame's to detect presses and releases,
+// so an InputSource can be fed from a replay, a network stream or a test.
+type InputSource interface {
+	// CursorPosition returns the mouse cursor position.
+	CursorPosition() (x, y int)
+
+	// Wheel returns the wheel movement since the last frame.
+	Wheel() (xoff, yoff float64)
+
+	// IsMouseButtonPressed reports whether the mouse button is pressed.
+	IsMouseButtonPressed(button ebiten.MouseButton) bool
+
+	// IsKeyPressed reports whether the key is pressed.
+	IsKeyPressed(key ebiten.Key) bool
+
+	// AppendInputChars appends the characters typed since the last frame to runes and returns the extended slice.
+	AppendInputChars(runes []rune) []rune
+}
+
+var (
+	inputMouseButtons = []ebiten.MouseButton{ebiten.MouseButtonLeft, ebiten.MouseButtonRight, ebiten.MouseButtonMiddle}
+	inputKeys         = []ebiten.Key{
+		ebiten.KeyAlt, ebiten.KeyBackspace, ebiten.KeyControl, ebiten.KeyEnter, ebiten.KeyShift, ebiten.KeySpace, ebiten.KeyTab,
+		ebiten.KeyDelete, ebiten.KeyArrowLeft, ebiten.KeyArrowRight, ebiten.KeyArrowUp, ebiten.KeyArrowDown,
+		ebiten.KeyHome, ebiten.KeyEnd, ebiten.KeyEscape, ebiten.KeyPageUp, ebiten.KeyPageDown,
+		ebiten.KeyMeta, ebiten.KeyA, ebiten.KeyC, ebiten.KeyV, ebiten.KeyX,
+	}
+)
+
+const (
+	// keyRepeatDelay is the number of ticks a key has to be held before it starts repeating.
+	keyRepeatDelay = 24
+
+	// keyRepeatInterval is the number of ticks between repeats.
+	keyRepeatInterval = 4
+)
+
+// inputFrame is the input of one frame after touches and the gamepad are mapped onto the mouse and the keyboard.
+// inputFrame is what InputRecorder records and InputPlayer replays.
+type inputFrame struct {
+	cursor    image.Point
+	mouseDown int
+	scroll    image.Point
+	keyDown   int
+	nav       int
+	touch     bool
+	chars     []rune
+}
+
+func (c *Context) updateInput() {
+	f := &c.inputFrame
+	if c.player != nil {
+		c.player.next(f)
+	} else {
+		c.readInput(f)
+	}
+	if c.recorder != nil {
+		c.recorder.record(f)
+	}
+	c.applyInput(f)
+}
+
+// readInput reads the input of this frame from the input source.
+func (c *Context) readInput(f *inputFrame) {
+	cx, cy := c.input.CursorPosition()
+	if p := image.Pt(cx, cy); p != c.lastInputCursor {
+		// the mouse is used again
+		c.touchActive = false
+		c.lastInputCursor = p
+	}
+	var touching bool
+	if touch, ok := c.input.(TouchInputSource); ok {
+		touching = c.updateTouch(touch)
+	}
+	if touching {
+		cx, cy = c.touch.pos.X, c.touch.pos.Y
+	}
+	cx, cy = c.screenToUI(cx, cy)
+	gamepad, _ := c.input.(GamepadInputSource)
+	if gamepad != nil && !touching {
+		switch c.gamepadMode {
+		case GamepadModeNavigation:
+			c.updateGamepadNav(gamepad)
+		case GamepadModeVirtualCursor:
+			cx, cy = c.updateVirtualCursor(gamepad, cx, cy)
+		}
+	}
+	if wx, wy := c.input.Wheel(); wx != 0 || wy != 0 {
+		c.inputScroll(int(wx*-30), int(wy*-30))
+	}
+
+	f.cursor = image.Pt(cx, cy)
+	f.mouseDown = 0
+	for _, b := range inputMouseButtons {
+		if c.input.IsMouseButtonPressed(b) || c.isVirtualCursorButtonPressed(gamepad, b) || (touching && c.isTouchButtonPressed(b)) {
+			f.mouseDown |= mouseButtonToInt(b)
+		}
+	}
+	// the scroll and the navigation are accumulated into the context by touches and the gamepad.
+	f.scroll = c.scrollDelta
+	f.nav = c.navPressed
+	f.touch = c.touchActive
+	f.keyDown = 0
+	for _, k := range inputKeys {
+		if c.input.IsKeyPressed(k) {
+			f.keyDown |= keyToInt(k)
+		}
+	}
+	f.chars = c.input.AppendInputChars(f.chars[:0])
+}
+
+// applyInput updates the input state from the input of this frame.
+func (c *Context) applyInput(f *inputFrame) {
+	cx, cy := f.cursor.X, f.cursor.Y
+	c.inputMouseMove(cx, cy)
+	c.scrollDelta = f.scroll
+	c.navPressed = f.nav
+	c.touchActive = f.touch
+	for _, b := range inputMouseButtons {
+		down := c.mouseDown&mouseButtonToInt(b) != 0
+		pressed := f.mouseDown&mouseButtonToInt(b) != 0
+		if pressed && !down {
+			c.inputMouseDown(cx, cy, b)
+		} else if !pressed && down {
+			c.inputMouseUp(cx, cy, b)
+		}
+	}
+	for _, k := range inputKeys {
+		down := c.keyDown&keyToInt(k) != 0
+		if pressed := f.keyDown&keyToInt(k) != 0; pressed && !down {
+			c.inputKeyDown(k)
+		} else if pressed && down {
+			c.inputKeyRepeat(k)
+		} else if !pressed && down {
+			c.inputKeyUp(k)
+		}
+	}
+	c.inputChars = append(c.inputChars[:0], f.chars...)
+}
+
+// screenToUI converts the pointer position on the screen to the UI coordinates
+// with the inverse of the transform and the scale.
+func (c *Context) screenToUI(x, y int) (int, int) {
+	fx, fy := float64(x), float64(y)
+	if c.invertible {
+		fx, fy = c.inverse.Apply(fx, fy)
+	}
+	s := c.uiScale()
+	return int(math.Floor(fx / s)), int(math.Floor(fy / s))
+}
+
+// usesIME reports whether text is input through the IME-aware textinput.Field.
+// This is true only when the input is read from Ebitengine, not replayed.
+func (c *Context) usesIME() bool {
+	return c.ime && c.player == nil
+}
+
 func (c *Context) inputMouseMove(x, y int) {
 	c.mousePos = image.Pt(x, y)
 }
@@ -53,15 +215,83 @@ func keyToInt(key ebiten.Key) int {
 		return keyBackspace
 	case ebiten.KeyEnter:
 		return keyReturn
+	case ebiten.KeyTab:
+		return keyTab
+	case ebiten.KeySpace:
+		return keySpace
+	case ebiten.KeyDelete:
+		return keyDelete
+	case ebiten.KeyArrowLeft:
+		return keyArrowLeft
+	case ebiten.KeyArrowRight:
+		return keyArrowRight
+	case ebiten.KeyArrowUp:
+		return keyArrowUp
+	case ebiten.KeyArrowDown:
+		return keyArrowDown
+	case ebiten.KeyHome:
+		return keyHome
+	case ebiten.KeyEnd:
+		return keyEnd
+	case ebiten.KeyEscape:
+		return keyEscape
+	case ebiten.KeyPageUp:
+		return keyPageUp
+	case ebiten.KeyPageDown:
+		return keyPageDown
+	case ebiten.KeyMeta:
+		return keyMeta
+	case ebiten.KeyA:
+		return keyA
+	case ebiten.KeyC:
+		return keyC
+	case ebiten.KeyV:
+		return keyV
+	case ebiten.KeyX:
+		return keyX
 	}
 	return 0
 }
 
 func (c *Context) inputKeyDown(key ebiten.Key) {
-	c.keyPressed |= keyToInt(key)
-	c.keyDown |= keyToInt(key)
+	k := keyToInt(key)
+	if k == 0 {
+		return
+	}
+	c.keyPressed |= k
+	c.keyDown |= k
+	c.keyDownTicks[bits.TrailingZeros(uint(k))] = c.tick
+}
+
+// inputKeyRepeat presses the held key again if it is repeatable and its repeat timing has come,
+// in the same way as inpututil.KeyPressDuration-based repeating.
+func (c *Context) inputKeyRepeat(key ebiten.Key) {
+	k := keyToInt(key)
+	if (k & keyRepeatable) == 0 {
+		return
+	}
+	d := c.tick - c.keyDownTicks[bits.TrailingZeros(uint(k))]
+	if d >= keyRepeatDelay && (d-keyRepeatDelay)%keyRepeatInterval == 0 {
+		c.keyPressed |= k
+	}
 }
 
 func (c *Context) inputKeyUp(key ebiten.Key) {
 	c.keyDown &= ^keyToInt(key)
 }
+
+func (c *Context) wantsMouse() bool {
+	// nextHoverRoot is the window under the cursor in the last frame.
+	return c.nextHoverRoot != nil || (c.focus != 0 && c.mouseDown != 0)
+}
+
+// wantsKeyboard reports whether a text field is being edited,
+// or a control has the keyboard focus given by the keyboard or gamepad navigation.
+// A click on a control doesn't make the debug UI want the keyboard.
+func (c *Context) wantsKeyboard() bool {
+	if _, ok := c.textFields[c.focus]; ok && c.focus != 0 {
+		return true
+	}
+	// navFocus is set only by the navigation, and is dropped when the control is not updated.
+	return c.navFocus != 0
+}
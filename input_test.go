@@ -0,0 +1,263 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestWantsKeyboard(t *testing.T) {
+	input := newTestInput()
+	d := newTestUI(input)
+	buf := "text"
+	var buttonRect, textBoxRect image.Rectangle
+	f := func(ctx *Context) {
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(res Response, layout Layout) {
+			ctx.SetLayoutRow([]int{-1}, 0)
+			ctx.Button("Button")
+			buttonRect = ctx.lastRect
+			ctx.TextBox(&buf)
+			textBoxRect = ctx.lastRect
+		})
+	}
+	d.Update(f)
+
+	// the window is hovered in the frame after the cursor enters it.
+	input.cursor = buttonRect.Min.Add(image.Pt(2, 2))
+	d.Update(f)
+	click(d, input, buttonRect.Min.Add(image.Pt(2, 2)), f)
+	if d.WantsKeyboard() {
+		t.Errorf("WantsKeyboard() after clicking a button = true, want false")
+	}
+
+	click(d, input, textBoxRect.Min.Add(image.Pt(2, 2)), f)
+	if !d.WantsKeyboard() {
+		t.Errorf("WantsKeyboard() after clicking a text box = false, want true")
+	}
+	press(d, input, ebiten.KeyEscape, f)
+	if d.WantsKeyboard() {
+		t.Errorf("WantsKeyboard() after Escape in a text box = true, want false")
+	}
+
+	press(d, input, ebiten.KeyTab, f)
+	if !d.WantsKeyboard() {
+		t.Errorf("WantsKeyboard() after Tab = false, want true")
+	}
+	press(d, input, ebiten.KeyEscape, f)
+	if d.WantsKeyboard() {
+		t.Errorf("WantsKeyboard() after Escape = true, want false")
+	}
+}
+
+func TestInputSource(t *testing.T) {
+	input := newTestInput()
+	d := newTestUI(input)
+	buf := ""
+	var submitted bool
+	var buttonRect, textBoxRect image.Rectangle
+	var scroll image.Point
+	f := func(ctx *Context) {
+		ctx.Window("Window", image.Rect(10, 10, 210, 110), func(res Response, layout Layout) {
+			scroll = layout.Scroll
+			ctx.SetLayoutRow([]int{-1}, 0)
+			if ctx.Button("Button")&ResponseSubmit != 0 {
+				submitted = true
+			}
+			buttonRect = ctx.lastRect
+			ctx.TextBox(&buf)
+			textBoxRect = ctx.lastRect
+			// make the window scrollable.
+			ctx.SetLayoutRow([]int{-1}, 200)
+			ctx.Text("")
+		})
+	}
+	d.Update(f)
+
+	// the window is hovered in the frame after the cursor enters it.
+	input.cursor = buttonRect.Min.Add(image.Pt(2, 2))
+	d.Update(f)
+	click(d, input, buttonRect.Min.Add(image.Pt(2, 2)), f)
+	if !submitted {
+		t.Errorf("the button is not submitted by the mouse button of the input source")
+	}
+
+	click(d, input, textBoxRect.Min.Add(image.Pt(2, 2)), f)
+	input.chars = append(input.chars, []rune("hi")...)
+	d.Update(f)
+	if buf != "hi" {
+		t.Errorf("the text typed by the input source: got %q, want %q", buf, "hi")
+	}
+
+	input.wheel[1] = -1
+	d.Update(f)
+	input.wheel[1] = 0
+	d.Update(f)
+	if scroll.Y <= 0 {
+		t.Errorf("the scroll by the wheel of the input source: got %v, want a positive Y", scroll)
+	}
+}
+
+func TestKeyRepeat(t *testing.T) {
+	testCases := []struct {
+		name string
+		key  ebiten.Key
+		bit  int
+		want []int
+	}{
+		{
+			name: "repeatable",
+			key:  ebiten.KeyBackspace,
+			bit:  keyBackspace,
+			want: []int{0, keyRepeatDelay, keyRepeatDelay + keyRepeatInterval, keyRepeatDelay + 2*keyRepeatInterval},
+		},
+		{
+			name: "not repeatable",
+			key:  ebiten.KeyEnter,
+			bit:  keyReturn,
+			want: []int{0},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := newTestInput()
+			d := newTestUI(input)
+			d.Update(func(ctx *Context) {})
+
+			// hold the key and record the frames where it is pressed.
+			input.keys[tc.key] = true
+			var got []int
+			for i := 0; i < keyRepeatDelay+3*keyRepeatInterval-1; i++ {
+				d.Update(func(ctx *Context) {
+					if ctx.keyPressed&tc.bit != 0 {
+						got = append(got, i)
+					}
+				})
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("pressed frames: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestKeyDownUnknownKey(t *testing.T) {
+	var c Context
+	c.inputKeyDown(ebiten.KeyF1)
+	if c.keyDown != 0 || c.keyPressed != 0 {
+		t.Errorf("keyDown and keyPressed: got %d and %d, want 0 and 0", c.keyDown, c.keyPressed)
+	}
+}
+
+func TestScreenToUI(t *testing.T) {
+	translate := func(x, y float64) *ebiten.GeoM {
+		var g ebiten.GeoM
+		g.Translate(x, y)
+		return &g
+	}
+	scale := func(x, y float64) *ebiten.GeoM {
+		var g ebiten.GeoM
+		g.Scale(x, y)
+		return &g
+	}
+	rotate := func() *ebiten.GeoM {
+		var g ebiten.GeoM
+		g.Rotate(math.Pi / 2)
+		// keep the screen pixels off the boundaries of the UI pixels.
+		g.Translate(0.5, 0.5)
+		return &g
+	}
+	testCases := []struct {
+		name      string
+		transform *ebiten.GeoM
+		scale     float64
+		screen    image.Point
+		want      image.Point
+	}{
+		{
+			name:   "no transform",
+			scale:  1,
+			screen: image.Pt(10, 20),
+			want:   image.Pt(10, 20),
+		},
+		{
+			name:   "scale",
+			scale:  2,
+			screen: image.Pt(21, 41),
+			want:   image.Pt(10, 20),
+		},
+		{
+			name:   "negative",
+			scale:  2,
+			screen: image.Pt(-1, -3),
+			want:   image.Pt(-1, -2),
+		},
+		{
+			name:      "identity",
+			transform: &ebiten.GeoM{},
+			scale:     1,
+			screen:    image.Pt(10, 20),
+			want:      image.Pt(10, 20),
+		},
+		{
+			name:      "translate",
+			transform: translate(100, 50),
+			scale:     1,
+			screen:    image.Pt(110, 70),
+			want:      image.Pt(10, 20),
+		},
+		{
+			name:      "transform scale",
+			transform: scale(2, 4),
+			scale:     1,
+			screen:    image.Pt(21, 83),
+			want:      image.Pt(10, 20),
+		},
+		{
+			name:      "transform and scale",
+			transform: translate(100, 50),
+			scale:     2,
+			screen:    image.Pt(121, 91),
+			want:      image.Pt(10, 20),
+		},
+		{
+			name:      "rotate",
+			transform: rotate(),
+			scale:     1,
+			screen:    image.Pt(-20, 10),
+			want:      image.Pt(9, 20),
+		},
+		{
+			name:      "not invertible",
+			transform: scale(0, 1),
+			scale:     1,
+			screen:    image.Pt(10, 20),
+			want:      image.Pt(10, 20),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Context{scale: tc.scale}
+			if tc.transform != nil {
+				c.setTransform(*tc.transform)
+			}
+			if x, y := c.screenToUI(tc.screen.X, tc.screen.Y); image.Pt(x, y) != tc.want {
+				t.Errorf("screenToUI(%d, %d) = (%d, %d), want %v", tc.screen.X, tc.screen.Y, x, y, tc.want)
+			}
+
+			// the transform maps the UI pixel back to the pixel on the screen.
+			if tc.transform == nil || !c.invertible {
+				return
+			}
+			sx, sy := c.transform.Apply((float64(tc.want.X)+0.5)*tc.scale, (float64(tc.want.Y)+0.5)*tc.scale)
+			if x, y := c.screenToUI(int(math.Floor(sx)), int(math.Floor(sy))); image.Pt(x, y) != tc.want {
+				t.Errorf("screenToUI of the transformed center of %v = (%d, %d), want %v", tc.want, x, y, tc.want)
+			}
+		})
+	}
+}
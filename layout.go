@@ -7,9 +7,15 @@ import "image"
 
 func (c *Context) pushLayout(body image.Rectangle, scroll image.Point) {
 	// push()
+	// reuse the widths of the layout previously pushed at this depth.
+	var widths []int
+	if n := len(c.layoutStack); n < cap(c.layoutStack) {
+		widths = c.layoutStack[:n+1][n].widths[:0]
+	}
 	c.layoutStack = append(c.layoutStack, layout{
-		body: body.Sub(scroll),
-		max:  image.Pt(-0x1000000, -0x1000000),
+		body:   body.Sub(scroll),
+		max:    image.Pt(-0x1000000, -0x1000000),
+		widths: widths,
 	})
 	c.SetLayoutRow([]int{0}, 0)
 }
@@ -66,7 +72,7 @@ func (c *Context) layoutNext() image.Rectangle {
 		res.Max.X = res.Min.X + c.style.size.X + c.style.padding*2
 	}
 	if res.Dy() == 0 {
-		res.Max.Y = res.Min.Y + c.style.size.Y + c.style.padding*2
+		res.Max.Y = res.Min.Y + c.defaultRowHeight()
 	}
 	if res.Dx() < 0 {
 		res.Max.X += layout.body.Dx() - res.Min.X + 1
@@ -91,3 +97,8 @@ func (c *Context) layoutNext() image.Rectangle {
 	c.lastRect = res
 	return c.lastRect
 }
+
+// defaultRowHeight returns the height of a control when the row height is 0.
+func (c *Context) defaultRowHeight() int {
+	return c.style.size.Y + c.extraHeight() + c.style.padding*2
+}
@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"unsafe"
+)
+
+// listBox does a list of the items in a scrollable panel.
+// index is used for the single selection, and selected is used for the multiple selection.
+func (c *Context) listBox(name string, items []string, index *int, selected map[int]bool, multiple bool, opt option) Response {
+	if multiple && selected == nil {
+		panic("selected must not be nil")
+	}
+	// this is the same as the ID of the panel.
+	id := c.id([]byte(name))
+	var res Response
+	c.panel(name, opt, func(layout Layout) {
+		if multiple {
+			if idx := c.poolGet(c.listBoxPool[:], id); idx >= 0 {
+				c.poolUpdate(c.listBoxPool[:], idx)
+			}
+		}
+		res = c.listBoxRows(id, items, index, selected, multiple, layout)
+	})
+	return res
+}
+
+// listBoxRows does the rows of a list box.
+// Only the visible rows are laid out and drawn, and the space of the other rows is reserved
+// so that the panel scrolls as if all the rows were there.
+func (c *Context) listBoxRows(id ID, items []string, index *int, selected map[int]bool, multiple bool, layout Layout) Response {
+	var res Response
+	n := len(items)
+	pitch := c.defaultRowHeight() + c.style.spacing
+	top := layout.Scroll.Y - c.style.padding
+	first := clamp(top/pitch, 0, n)
+	last := clamp((top+layout.Body.Dy())/pitch+1, first, n)
+
+	if first > 0 {
+		c.SetLayoutRow([]int{-1}, first*pitch-c.style.spacing)
+		c.layoutNext()
+	}
+	c.SetLayoutRow([]int{-1}, 0)
+	for i := first; i < last; i++ {
+		itemID := c.id(ptrToBytes(unsafe.Pointer(&items[i])))
+		res |= c.Control(itemID, 0, func(r image.Rectangle) Response {
+			var res Response
+			if (c.mousePressed == mouseLeft && c.focus == itemID) || c.navActivated(itemID) {
+				if c.selectListBoxItem(id, i, index, selected, multiple) {
+					res |= ResponseChange
+				}
+			}
+
+			// draw
+			if (!multiple && *index == i) || (multiple && selected[i]) {
+				c.drawFrame(r, ColorButtonFocus)
+			} else if c.hover == itemID {
+				c.drawFrame(r, ColorButtonHover)
+			}
+			c.drawControlText(items[i], r, ColorText, 0)
+			return res
+		})
+	}
+	if last < n {
+		c.SetLayoutRow([]int{-1}, (n-last)*pitch-c.style.spacing)
+		c.layoutNext()
+	}
+	return res
+}
+
+// selectListBoxItem updates the selection by clicking the item i, and reports whether the selection is changed.
+//
+// With the multiple selection, Ctrl-click toggles the item, and Shift-click selects the items
+// from the last clicked item to the item.
+// The last clicked items are kept in a pool, so the list box used least recently loses its item first.
+func (c *Context) selectListBoxItem(id ID, i int, index *int, selected map[int]bool, multiple bool) bool {
+	if !multiple {
+		if *index == i {
+			return false
+		}
+		*index = i
+		return true
+	}
+
+	ctrl := (c.keyDown & (keyControl | keyMeta)) != 0
+	shift := (c.keyDown & keyShift) != 0
+	idx := c.poolGet(c.listBoxPool[:], id)
+	if shift && idx >= 0 {
+		anchor := c.listBoxAnchors[idx]
+		if !ctrl {
+			clear(selected)
+		}
+		for j := min(anchor, i); j <= max(anchor, i); j++ {
+			selected[j] = true
+		}
+		return true
+	}
+
+	if idx < 0 {
+		idx = c.poolInit(c.listBoxPool[:], id)
+	}
+	c.listBoxAnchors[idx] = i
+	if ctrl {
+		if selected[i] {
+			delete(selected, i)
+		} else {
+			selected[i] = true
+		}
+		return true
+	}
+	if len(selected) == 1 && selected[i] {
+		return false
+	}
+	clear(selected)
+	selected[i] = true
+	return true
+}
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"maps"
+	"testing"
+)
+
+func TestMultiSelectListBoxNilMap(t *testing.T) {
+	d := newTestUI(newTestInput())
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MultiSelectListBox with a nil map must panic")
+		}
+	}()
+	d.Update(func(ctx *Context) {
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(res Response, layout Layout) {
+			ctx.MultiSelectListBox("List", []string{"a", "b"}, nil)
+		})
+	})
+}
+
+func TestSelectListBoxItem(t *testing.T) {
+	const (
+		click = iota
+		ctrlClick
+		shiftClick
+	)
+	type step struct {
+		kind int
+		item int
+	}
+	testCases := []struct {
+		name  string
+		steps []step
+		want  map[int]bool
+	}{
+		{
+			name:  "click",
+			steps: []step{{click, 1}, {click, 3}},
+			want:  map[int]bool{3: true},
+		},
+		{
+			name:  "ctrl-click",
+			steps: []step{{click, 1}, {ctrlClick, 3}, {ctrlClick, 4}, {ctrlClick, 1}},
+			want:  map[int]bool{3: true, 4: true},
+		},
+		{
+			name:  "shift-click",
+			steps: []step{{click, 2}, {shiftClick, 5}},
+			want:  map[int]bool{2: true, 3: true, 4: true, 5: true},
+		},
+		{
+			name:  "shift-click backward",
+			steps: []step{{click, 5}, {shiftClick, 2}, {shiftClick, 6}},
+			want:  map[int]bool{5: true, 6: true},
+		},
+		{
+			name:  "shift-click without anchor",
+			steps: []step{{shiftClick, 2}},
+			want:  map[int]bool{2: true},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newTestUI(newTestInput()).ctx
+			c.tick = 1
+			selected := map[int]bool{}
+			for _, s := range tc.steps {
+				switch s.kind {
+				case click:
+					c.keyDown = 0
+				case ctrlClick:
+					c.keyDown = keyControl
+				case shiftClick:
+					c.keyDown = keyShift
+				}
+				c.selectListBoxItem(1, s.item, nil, selected, true)
+			}
+			if !maps.Equal(selected, tc.want) {
+				t.Errorf("got %v, want %v", selected, tc.want)
+			}
+		})
+	}
+}
+
+func TestListBoxAnchorsPruned(t *testing.T) {
+	c := newTestUI(newTestInput()).ctx
+	selected := map[int]bool{}
+	for id := ID(1); id <= listBoxPoolSize*2; id++ {
+		c.tick++
+		c.selectListBoxItem(id, 0, nil, selected, true)
+	}
+	// only the recently clicked list boxes keep their anchors.
+	if idx := c.poolGet(c.listBoxPool[:], 1); idx >= 0 {
+		t.Errorf("the anchor of the least recently used list box is kept")
+	}
+	if idx := c.poolGet(c.listBoxPool[:], listBoxPoolSize*2); idx < 0 {
+		t.Errorf("the anchor of the most recently used list box is dropped")
+	}
+}
@@ -0,0 +1,209 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"slices"
+	"sort"
+)
+
+// updateNavControl registers the control at rect as a keyboard focus target.
+// Only the controls in the navigation root are targets, in layout order.
+func (c *Context) updateNavControl(id ID, rect image.Rectangle, opt option) {
+	if id == 0 || (opt&optionNoInteract) != 0 {
+		return
+	}
+	if c.navFocus == id {
+		c.keepNavFocus = true
+	}
+	if c.inRoot(c.navRoot) {
+		c.navIDs = append(c.navIDs, id)
+		c.navRects = append(c.navRects, rect)
+	}
+}
+
+// navActivated reports whether the control has the keyboard focus and is activated by Enter or Space.
+func (c *Context) navActivated(id ID) bool {
+	return id != 0 && c.navFocus == id && ((c.keyPressed&(keyReturn|keySpace)) != 0 || (c.navPressed&navActivate) != 0)
+}
+
+// navStep returns the direction the arrow keys step the value of the control with the keyboard focus by.
+// Right and Up step forward, and Left and Down step backward.
+func (c *Context) navStep(id ID) int {
+	if id == 0 || c.navFocus != id {
+		return 0
+	}
+	var d int
+	if (c.keyPressed & (keyArrowRight | keyArrowUp)) != 0 {
+		d++
+	}
+	if (c.keyPressed & (keyArrowLeft | keyArrowDown)) != 0 {
+		d--
+	}
+	return d
+}
+
+// moveNavFocus moves the keyboard focus by delta controls, wrapping around the navigation root.
+func (c *Context) moveNavFocus(delta int) {
+	n := len(c.navIDs)
+	if n == 0 {
+		return
+	}
+	idx := slices.Index(c.navIDs, c.navFocus)
+	if idx < 0 {
+		if delta > 0 {
+			idx = 0
+		} else {
+			idx = n - 1
+		}
+	} else {
+		idx = ((idx+delta)%n + n) % n
+	}
+	c.setNavFocus(c.navIDs[idx])
+}
+
+// moveNavFocusTo moves the keyboard focus to the nearest control in the direction (dx, dy)
+// from the control with the keyboard focus.
+// If no control has the keyboard focus, the first control in the direction is focused.
+func (c *Context) moveNavFocusTo(dx, dy int) {
+	idx := slices.Index(c.navIDs, c.navFocus)
+	if idx < 0 {
+		c.moveNavFocus(dx + dy)
+		return
+	}
+	from := c.navRects[idx]
+	best := -1
+	var bestDist, bestOffset int
+	for i, r := range c.navRects {
+		// along is the distance from the focused control in the direction.
+		// gap and offset are the distances between the edges and between the centers across the direction.
+		var along, gap, offset int
+		switch {
+		case dx > 0:
+			along = r.Min.X - from.Max.X
+		case dx < 0:
+			along = from.Min.X - r.Max.X
+		case dy > 0:
+			along = r.Min.Y - from.Max.Y
+		case dy < 0:
+			along = from.Min.Y - r.Max.Y
+		}
+		if along < 0 {
+			continue
+		}
+		if dx != 0 {
+			gap = max(r.Min.Y-from.Max.Y, from.Min.Y-r.Max.Y, 0)
+			offset = (r.Min.Y + r.Max.Y) - (from.Min.Y + from.Max.Y)
+		} else {
+			gap = max(r.Min.X-from.Max.X, from.Min.X-r.Max.X, 0)
+			offset = (r.Min.X + r.Max.X) - (from.Min.X + from.Max.X)
+		}
+		offset = max(offset, -offset)
+		// prefer the controls in line with the focused control, and then the one closer to its center.
+		dist := along + 2*gap
+		if best < 0 || dist < bestDist || (dist == bestDist && offset < bestOffset) {
+			best = i
+			bestDist = dist
+			bestOffset = offset
+		}
+	}
+	if best < 0 {
+		return
+	}
+	c.setNavFocus(c.navIDs[best])
+}
+
+func (c *Context) setNavFocus(id ID) {
+	c.navFocus = id
+	c.keepNavFocus = true
+	// focus the control too so that a text box starts editing.
+	c.SetFocus(id)
+}
+
+func (c *Context) beginNav() {
+	// the hover root is used as the navigation root if any. otherwise the front-most window is used.
+	// with the gamepad navigation, the front-most window is always used as the shoulder buttons switch it.
+	c.navRoot = c.hoverRoot
+	if c.navRoot == nil || c.gamepadMode == GamepadModeNavigation {
+		c.navRoot = c.frontRoot
+	}
+	c.navIDs = c.navIDs[:0]
+	c.navRects = c.navRects[:0]
+	if c.mousePressed != 0 {
+		c.navFocus = 0
+	}
+}
+
+func (c *Context) endNav() {
+	if (c.keyPressed & keyTab) != 0 {
+		if (c.keyDown & keyShift) != 0 {
+			c.navPressed |= navPrev
+		} else {
+			c.navPressed |= navNext
+		}
+	}
+	if (c.keyPressed & keyEscape) != 0 {
+		c.navPressed |= navCancel
+	}
+	if (c.navPressed & navNext) != 0 {
+		c.moveNavFocus(1)
+	}
+	if (c.navPressed & navPrev) != 0 {
+		c.moveNavFocus(-1)
+	}
+	if (c.navPressed & navLeft) != 0 {
+		c.moveNavFocusTo(-1, 0)
+	}
+	if (c.navPressed & navRight) != 0 {
+		c.moveNavFocusTo(1, 0)
+	}
+	if (c.navPressed & navUp) != 0 {
+		c.moveNavFocusTo(0, -1)
+	}
+	if (c.navPressed & navDown) != 0 {
+		c.moveNavFocusTo(0, 1)
+	}
+	if (c.navPressed & navCancel) != 0 {
+		// drop the focus first so that a text box stops editing. then drop the keyboard focus.
+		if c.focus != 0 {
+			c.SetFocus(0)
+		} else {
+			c.navFocus = 0
+		}
+	}
+	if (c.navPressed & navNextWindow) != 0 {
+		c.switchWindow(1)
+	}
+	if (c.navPressed & navPrevWindow) != 0 {
+		c.switchWindow(-1)
+	}
+	c.navPressed = 0
+
+	// unset keyboard focus if the control was not touched this frame
+	if !c.keepNavFocus {
+		c.navFocus = 0
+	}
+	c.keepNavFocus = false
+}
+
+// switchWindow brings the next (delta > 0) or the previous (delta < 0) window to front.
+func (c *Context) switchWindow(delta int) {
+	if len(c.rootList) < 2 {
+		return
+	}
+	roots := slices.Clone(c.rootList)
+	sort.SliceStable(roots, func(i, j int) bool {
+		return roots[i].zIndex < roots[j].zIndex
+	})
+	if delta > 0 {
+		// send the front-most window to back by bringing the others to front in order
+		for _, cnt := range roots[:len(roots)-1] {
+			c.bringToFront(cnt)
+		}
+	} else {
+		c.bringToFront(roots[0])
+	}
+	c.navFocus = 0
+}
@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestNavFocusFromKeyboardOnly(t *testing.T) {
+	input := newTestInput()
+	d := newTestUI(input)
+	var buttonID ID
+	f := func(ctx *Context) {
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(res Response, layout Layout) {
+			ctx.Button("Button")
+			buttonID = ctx.LastID
+		})
+	}
+	d.Update(f)
+
+	click(d, input, image.Pt(20, 30), f)
+	if d.ctx.navFocus != 0 {
+		t.Errorf("navFocus after a click = %v, want 0", d.ctx.navFocus)
+	}
+
+	// Tab focuses the button.
+	press(d, input, ebiten.KeyTab, f)
+	if d.ctx.navFocus != buttonID {
+		t.Errorf("navFocus after Tab = %v, want %v", d.ctx.navFocus, buttonID)
+	}
+
+	// a click drops the keyboard focus.
+	click(d, input, image.Pt(20, 30), f)
+	if d.ctx.navFocus != 0 {
+		t.Errorf("navFocus after a click = %v, want 0", d.ctx.navFocus)
+	}
+}
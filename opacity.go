@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"image/color"
+)
+
+const (
+	// minWindowOpacity is the minimum opacity set by the opacity control, so that a window doesn't disappear.
+	minWindowOpacity = 0.1
+
+	// fadedWindowOpacity is the opacity of a faded window relative to its opacity.
+	fadedWindowOpacity = 0.3
+
+	// windowFadeStep is how much a window fades in or out per tick.
+	windowFadeStep = 0.1
+)
+
+// updateWindowAlpha updates the opacity and the fading of the root container,
+// and returns the alpha applied to the commands of the container.
+func (c *Context) updateWindowAlpha(cnt *container, opacity float64, opt option) float64 {
+	if cnt.opacity == 0 {
+		cnt.opacity = 1
+		if opacity > 0 {
+			cnt.opacity = min(opacity, 1)
+		}
+	}
+	if (opt & optionFadeWhenNotHovered) != 0 {
+		if c.hoverRoot == cnt {
+			cnt.fade = max(cnt.fade-windowFadeStep, 0)
+		} else {
+			cnt.fade = min(cnt.fade+windowFadeStep, 1)
+		}
+	} else {
+		cnt.fade = 0
+	}
+	return cnt.opacity * (1 - cnt.fade*(1-fadedWindowOpacity))
+}
+
+// windowColor returns the color with the alpha of the current window applied.
+func (c *Context) windowColor(clr color.RGBA) color.RGBA {
+	if c.alpha >= 1 {
+		return clr
+	}
+	return scaleColor(clr, c.alpha)
+}
+
+// scaleColor returns the color with its alpha multiplied by a.
+func scaleColor(clr color.RGBA, a float64) color.RGBA {
+	// the color is premultiplied, so all the components are scaled.
+	a = clampF(a, 0, 1)
+	return color.RGBA{
+		R: uint8(float64(clr.R)*a + 0.5),
+		G: uint8(float64(clr.G)*a + 0.5),
+		B: uint8(float64(clr.B)*a + 0.5),
+		A: uint8(float64(clr.A)*a + 0.5),
+	}
+}
+
+// opacityControl is the control in the title bar to adjust the opacity of the window by dragging.
+func (c *Context) opacityControl(cnt *container, r image.Rectangle, opt option) {
+	id := c.id([]byte("!opacity"))
+	c.updateControl(id, r, opt)
+	if id == c.focus && c.mouseDown == mouseLeft && r.Dx() > 0 {
+		v := float64(c.mousePos.X-r.Min.X) / float64(r.Dx())
+		cnt.opacity = min(max(v, minWindowOpacity), 1)
+	}
+	c.drawFrame(r, ColorBase)
+	fill := r
+	fill.Max.X = r.Min.X + int(float64(r.Dx())*cnt.opacity)
+	c.drawRect(fill, c.style.colors[ColorButton])
+}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestWindowOpacity(t *testing.T) {
+	commands := func(opacity float64) []Command {
+		d := newTestUI(newTestInput())
+		d.SetCornerRadius(4)
+		d.SetBorderWidth(1)
+		d.SetShadowSize(4)
+		checked := true
+		clr := color.RGBA{R: 0x80, G: 0x40, A: 0xff}
+		d.Update(func(ctx *Context) {
+			ctx.WindowWithOptions("Window", image.Rect(10, 10, 310, 410), &WindowOptions{Opacity: opacity}, func(res Response, layout Layout) {
+				ctx.Text("Text")
+				ctx.Checkbox("Checkbox", &checked)
+				ctx.ColorPicker(&clr)
+				ctx.Draw(func(screen *ebiten.Image) {})
+			})
+		})
+		var cmds []Command
+		for it := d.Commands(); it.Next(); {
+			cmds = append(cmds, it.Command())
+		}
+		return cmds
+	}
+
+	const opacity = 0.5
+	opaque := commands(1)
+	translucent := commands(opacity)
+	if len(translucent) != len(opaque) {
+		t.Fatalf("the number of the commands: got %d, want %d", len(translucent), len(opaque))
+	}
+	types := map[CommandType]bool{}
+	for i, cmd := range translucent {
+		types[cmd.Type()] = true
+		if got, want := cmd.Color(), scaleColor(opaque[i].Color(), opacity); got != want {
+			t.Errorf("the color of the command #%d (type %d): got %v, want %v", i, cmd.Type(), got, want)
+		}
+	}
+	for _, typ := range []CommandType{CommandRect, CommandText, CommandIcon, CommandDraw, CommandRoundedRect, CommandOutline, CommandShadow} {
+		if !types[typ] {
+			t.Errorf("no command of the type %d is tested", typ)
+		}
+	}
+}
+
+func TestWindowFade(t *testing.T) {
+	input := newTestInput()
+	d := newTestUI(input)
+	var alphas []uint8
+	f := func(ctx *Context) {
+		ctx.WindowWithOptions("Window", image.Rect(10, 10, 110, 110), &WindowOptions{FadeWhenNotHovered: true}, func(res Response, layout Layout) {
+			ctx.Draw(func(screen *ebiten.Image) {})
+		})
+	}
+	drawAlpha := func() uint8 {
+		for it := d.Commands(); it.Next(); {
+			if cmd := it.Command(); cmd.Type() == CommandDraw {
+				return cmd.Color().A
+			}
+		}
+		t.Fatal("no CommandDraw")
+		return 0
+	}
+
+	// the window fades out step by step while the cursor is not over it.
+	input.cursor = image.Pt(200, 200)
+	var want []uint8
+	var fade float64
+	for i := 0; i < int(1/windowFadeStep)+2; i++ {
+		d.Update(f)
+		alphas = append(alphas, drawAlpha())
+		fade = min(fade+windowFadeStep, 1)
+		want = append(want, scaleColor(color.RGBA{0xff, 0xff, 0xff, 0xff}, 1-fade*(1-fadedWindowOpacity)).A)
+	}
+	for i := range want {
+		if alphas[i] != want[i] {
+			t.Errorf("the alpha while fading out: got %v, want %v", alphas, want)
+			break
+		}
+	}
+
+	// the window fades in while the cursor is over it.
+	input.cursor = image.Pt(50, 50)
+	last := alphas[len(alphas)-1]
+	for i := 0; i < int(1/windowFadeStep)+2; i++ {
+		d.Update(f)
+		a := drawAlpha()
+		if a < last {
+			t.Errorf("the alpha while fading in: got %d after %d", a, last)
+		}
+		last = a
+	}
+	if last != 0xff {
+		t.Errorf("the alpha after fading in: got %d, want %d", last, 0xff)
+	}
+}
@@ -0,0 +1,369 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+)
+
+const (
+	// plotDefaultRows is the height of a plot in rows when its height is not specified.
+	plotDefaultRows = 5
+
+	// plotGridLines is the approximate number of the grid lines of a plot in each direction.
+	plotGridLines = 4
+
+	// plotMaxGridLines is the maximum number of the grid lines of a plot in each direction.
+	plotMaxGridLines = plotGridLines*3 + 1
+
+	// plotGridAlpha is the alpha of the grid lines relative to the text color.
+	plotGridAlpha = 0.25
+)
+
+// seriesColors is the palette of the series without colors.
+var seriesColors = []color.RGBA{
+	{0x4e, 0x9a, 0xf0, 0xff},
+	{0xf0, 0x8a, 0x3e, 0xff},
+	{0x5c, 0xc0, 0x6a, 0xff},
+	{0xe0, 0x55, 0x5c, 0xff},
+	{0xa8, 0x7c, 0xe0, 0xff},
+	{0xe0, 0xc8, 0x4a, 0xff},
+}
+
+// seriesColor returns clr, or the color of the palette for the i-th series if clr is zero.
+func seriesColor(i int, clr color.RGBA) color.RGBA {
+	if clr != (color.RGBA{}) {
+		return clr
+	}
+	return seriesColors[i%len(seriesColors)]
+}
+
+// PlotSeries is a series of values drawn by PlotLines.
+type PlotSeries struct {
+	// Label is the name of the series shown in the legend and the tooltip.
+	Label string
+
+	// Values is the values of the series from the oldest to the newest.
+	// The values are placed at even intervals across the plot. NaN values are not drawn.
+	Values []float64
+
+	// Color is the color of the line.
+	// If Color is zero, a color of the palette is used.
+	Color color.RGBA
+}
+
+// PlotOptions represents options for PlotLines.
+type PlotOptions struct {
+	// Min and Max are the range of the vertical axis.
+	//
+	// If Min and Max are equal, the range is computed from the values every frame.
+	Min float64
+	Max float64
+
+	// Height is the height of the plot.
+	//
+	// If Height is 0, the plot is as tall as 5 rows.
+	Height int
+
+	// Color is the color of the line of the values.
+	// If Color is zero, a color of the palette is used.
+	Color color.RGBA
+
+	// Series is the other series drawn with the values.
+	// The legend is shown when Series is not empty.
+	Series []PlotSeries
+}
+
+// PlotBuffer is a fixed-size ring buffer of values for PlotLines.
+// When the buffer is full, pushing a value drops the oldest value.
+//
+// Use NewPlotBuffer to create a PlotBuffer.
+type PlotBuffer struct {
+	// values has every value twice, so that the values in the buffer are always contiguous.
+	values []float64
+
+	// head is the index of the next value.
+	head int
+
+	n int
+}
+
+// NewPlotBuffer returns a new PlotBuffer that holds the given number of values.
+func NewPlotBuffer(size int) *PlotBuffer {
+	return &PlotBuffer{
+		values: make([]float64, max(size, 1)*2),
+	}
+}
+
+// Push adds the value as the newest value.
+func (b *PlotBuffer) Push(value float64) {
+	size := len(b.values) / 2
+	b.values[b.head] = value
+	b.values[b.head+size] = value
+	b.head = (b.head + 1) % size
+	b.n = min(b.n+1, size)
+}
+
+// Values returns the values from the oldest to the newest.
+//
+// The returned slice is valid until the next Push or Reset call.
+func (b *PlotBuffer) Values() []float64 {
+	size := len(b.values) / 2
+	start := (b.head - b.n + size) % size
+	return b.values[start : start+b.n]
+}
+
+// Len returns the number of the values in the buffer.
+func (b *PlotBuffer) Len() int {
+	return b.n
+}
+
+// Reset removes all the values.
+func (b *PlotBuffer) Reset() {
+	b.head = 0
+	b.n = 0
+}
+
+// plotRange returns the range of the finite values.
+func plotRange(values ...[]float64) (lo, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, vs := range values {
+		for _, v := range vs {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				continue
+			}
+			lo = min(lo, v)
+			hi = max(hi, v)
+		}
+	}
+	if lo > hi {
+		return 0, 1
+	}
+	if lo == hi {
+		return lo - 0.5, hi + 0.5
+	}
+	return lo, hi
+}
+
+// niceStep returns a step of 1, 2 or 5 times a power of 10 that divides span into about n parts.
+func niceStep(span float64, n int) float64 {
+	x := span / float64(n)
+	if x <= 0 || math.IsNaN(x) || math.IsInf(x, 0) {
+		return 1
+	}
+	p := math.Pow(10, math.Floor(math.Log10(x)))
+	switch f := x / p; {
+	case f < 1.5:
+		return p
+	case f < 3:
+		return 2 * p
+	case f < 7:
+		return 5 * p
+	default:
+		return 10 * p
+	}
+}
+
+// appendGridValues appends the values of the grid lines between lo and hi to values,
+// and returns the extended slice and the step between them.
+func appendGridValues(values []float64, lo, hi float64) ([]float64, float64) {
+	step := niceStep(hi-lo, plotGridLines)
+	first := math.Ceil(lo / step)
+	// the number of the lines is limited in case the step is too small for the precision of the values.
+	for i := 0; i < plotMaxGridLines; i++ {
+		v := (first + float64(i)) * step
+		if v > hi {
+			break
+		}
+		values = append(values, v)
+	}
+	return values, step
+}
+
+// formatStepValue formats the value with the enough digits for the step.
+func formatStepValue(v, step float64) string {
+	digits := max(0, int(-math.Floor(math.Log10(step))))
+	return strconv.FormatFloat(v, 'f', digits, 64)
+}
+
+// plotInterpolate returns the value at the position t between the indices.
+func plotInterpolate(values []float64, t float64) float64 {
+	i := int(t)
+	if i >= len(values)-1 {
+		return values[len(values)-1]
+	}
+	f := t - float64(i)
+	return values[i]*(1-f) + values[i+1]*f
+}
+
+// plotColumnRange returns the range of the values covered by the column x of w columns.
+// The adjacent columns share their boundaries, so the line is connected and no spike is missed.
+func plotColumnRange(values []float64, x, w int) (lo, hi float64, ok bool) {
+	scale := float64(len(values)-1) / float64(max(w-1, 1))
+	t0 := max(float64(x)-0.5, 0) * scale
+	t1 := min(float64(x)+0.5, float64(w-1)) * scale
+	lo, hi = math.Inf(1), math.Inf(-1)
+	add := func(v float64) {
+		if math.IsNaN(v) {
+			return
+		}
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+	add(plotInterpolate(values, t0))
+	add(plotInterpolate(values, t1))
+	for i := int(math.Ceil(t0)); float64(i) <= t1; i++ {
+		add(values[i])
+	}
+	return lo, hi, lo <= hi
+}
+
+// plotArea is the area of a plot with its vertical range.
+type plotArea struct {
+	rect   image.Rectangle
+	lo, hi float64
+}
+
+// y returns the y position of the value.
+func (a *plotArea) y(v float64) int {
+	f := (v - a.lo) / (a.hi - a.lo)
+	return a.rect.Max.Y - 1 - int(clampF(f, 0, 1)*float64(a.rect.Dy()-1)+0.5)
+}
+
+// drawPlotGrid draws the horizontal grid lines with their values, and the vertical grid lines.
+func (c *Context) drawPlotGrid(a *plotArea) {
+	clr := scaleColor(c.style.colors[ColorText], plotGridAlpha)
+	var buf [plotMaxGridLines]float64
+	values, step := appendGridValues(buf[:0], a.lo, a.hi)
+	for _, v := range values {
+		y := a.y(v)
+		c.drawRect(image.Rect(a.rect.Min.X, y, a.rect.Max.X, y+1), clr)
+		str := formatStepValue(v, step)
+		x := a.rect.Max.X - c.textWidth(str) - c.style.padding
+		c.drawText(str, image.Pt(x, max(y-c.lineHeight(), a.rect.Min.Y)), clr)
+	}
+	for i := 1; i < plotGridLines; i++ {
+		x := a.rect.Min.X + i*a.rect.Dx()/plotGridLines
+		c.drawRect(image.Rect(x, a.rect.Min.Y, x+1, a.rect.Max.Y), clr)
+	}
+}
+
+// drawPlotLine draws the values as a line with a vertical span of a pixel wide in every column.
+func (c *Context) drawPlotLine(a *plotArea, values []float64, clr color.RGBA) {
+	if len(values) == 0 {
+		return
+	}
+	w := a.rect.Dx()
+	for x := 0; x < w; x++ {
+		lo, hi, ok := plotColumnRange(values, x, w)
+		if !ok {
+			continue
+		}
+		px := a.rect.Min.X + x
+		c.drawRect(image.Rect(px, a.y(hi), px+1, a.y(lo)+1), clr)
+	}
+}
+
+// legendSeries is a series shown in a legend.
+type legendSeries interface {
+	// legendEntry returns the label and the color of the series.
+	// The color is zero when a color of the palette is used.
+	legendEntry() (string, color.RGBA)
+}
+
+func (s PlotSeries) legendEntry() (string, color.RGBA) {
+	return s.Label, s.Color
+}
+
+func (s BarSeries) legendEntry() (string, color.RGBA) {
+	return s.Label, s.Color
+}
+
+// legend does a row of the labels of the series with their colors.
+func legend[S legendSeries](c *Context, series []S) {
+	c.SetLayoutRow([]int{-1}, 0)
+	c.Control(0, 0, func(r image.Rectangle) Response {
+		lh := c.lineHeight()
+		x := r.Min.X + c.style.padding
+		y := r.Min.Y + (r.Dy()-lh)/2
+		for i, s := range series {
+			label, clr := s.legendEntry()
+			c.drawRect(image.Rect(x, y, x+lh, y+lh).Inset(2), seriesColor(i, clr))
+			x += lh + c.style.spacing
+			c.drawText(label, image.Pt(x, y), c.style.colors[ColorText])
+			x += c.textWidth(label) + c.style.padding*2
+		}
+		return 0
+	})
+}
+
+func (c *Context) plotLines(label string, values []float64, opts *PlotOptions) {
+	if opts == nil {
+		opts = &PlotOptions{}
+	}
+	c.plotSeries = append(c.plotSeries[:0], PlotSeries{Label: label, Values: values, Color: opts.Color})
+	c.plotSeries = append(c.plotSeries, opts.Series...)
+	series := c.plotSeries
+	var a plotArea
+	if opts.Min != opts.Max {
+		a.lo, a.hi = min(opts.Min, opts.Max), max(opts.Min, opts.Max)
+	} else {
+		c.seriesValues = c.seriesValues[:0]
+		for _, s := range series {
+			c.seriesValues = append(c.seriesValues, s.Values)
+		}
+		a.lo, a.hi = plotRange(c.seriesValues...)
+	}
+	h := opts.Height
+	if h == 0 {
+		h = c.defaultRowHeight() * plotDefaultRows
+	}
+
+	c.LayoutColumn(func() {
+		c.SetLayoutRow([]int{-1}, h)
+		c.Control(0, 0, func(r image.Rectangle) Response {
+			c.drawFrame(r, ColorBase)
+			a.rect = r.Inset(c.style.padding / 2)
+			c.pushClipRect(a.rect)
+			defer c.popClipRect()
+
+			c.drawPlotGrid(&a)
+			for i, s := range series {
+				c.drawPlotLine(&a, s.Values, seriesColor(i, s.Color))
+			}
+			if len(label) > 0 {
+				c.drawText(label, a.rect.Min.Add(image.Pt(c.style.padding, 0)), c.style.colors[ColorText])
+			}
+
+			// show the values at the mouse cursor
+			if !c.mouseOver(a.rect) {
+				return 0
+			}
+			mx := c.mousePos.X
+			c.drawRect(image.Rect(mx, a.rect.Min.Y, mx+1, a.rect.Max.Y), c.style.colors[ColorText])
+			f := float64(mx-a.rect.Min.X) / float64(max(a.rect.Dx()-1, 1))
+			for i, s := range series {
+				if len(s.Values) == 0 {
+					continue
+				}
+				idx := int(f*float64(len(s.Values)-1) + 0.5)
+				v := s.Values[idx]
+				clr := seriesColor(i, s.Color)
+				if !math.IsNaN(v) {
+					y := a.y(v)
+					c.drawRect(image.Rect(mx-2, y-2, mx+3, y+3), clr)
+				}
+				c.addTooltipValue(s.Label, v, clr)
+			}
+			return 0
+		})
+
+		if len(opts.Series) > 0 {
+			legend(c, series)
+		}
+	})
+}
@@ -0,0 +1,271 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestPlotBuffer(t *testing.T) {
+	testCases := []struct {
+		name   string
+		size   int
+		pushes []float64
+		reset  bool
+		after  []float64
+		want   []float64
+	}{
+		{
+			name: "empty",
+			size: 3,
+			want: []float64{},
+		},
+		{
+			name:   "partial",
+			size:   3,
+			pushes: []float64{1, 2},
+			want:   []float64{1, 2},
+		},
+		{
+			name:   "full",
+			size:   3,
+			pushes: []float64{1, 2, 3},
+			want:   []float64{1, 2, 3},
+		},
+		{
+			name:   "wraparound",
+			size:   3,
+			pushes: []float64{1, 2, 3, 4, 5},
+			want:   []float64{3, 4, 5},
+		},
+		{
+			name:   "wraparound twice",
+			size:   3,
+			pushes: []float64{1, 2, 3, 4, 5, 6, 7},
+			want:   []float64{5, 6, 7},
+		},
+		{
+			name:   "reset",
+			size:   3,
+			pushes: []float64{1, 2, 3, 4},
+			reset:  true,
+			want:   []float64{},
+		},
+		{
+			name:   "push after reset",
+			size:   3,
+			pushes: []float64{1, 2, 3, 4},
+			reset:  true,
+			after:  []float64{5, 6},
+			want:   []float64{5, 6},
+		},
+		{
+			name:   "zero size",
+			size:   0,
+			pushes: []float64{1, 2},
+			want:   []float64{2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := NewPlotBuffer(tc.size)
+			for _, v := range tc.pushes {
+				b.Push(v)
+			}
+			if tc.reset {
+				b.Reset()
+			}
+			for _, v := range tc.after {
+				b.Push(v)
+			}
+			if got := b.Values(); !slices.Equal(got, tc.want) {
+				t.Errorf("Values() = %v, want %v", got, tc.want)
+			}
+			if got := b.Len(); got != len(tc.want) {
+				t.Errorf("Len() = %d, want %d", got, len(tc.want))
+			}
+		})
+	}
+}
+
+func TestPlotRange(t *testing.T) {
+	nan := math.NaN()
+	inf := math.Inf(1)
+	testCases := []struct {
+		name   string
+		values [][]float64
+		lo, hi float64
+	}{
+		{
+			name: "no values",
+			lo:   0,
+			hi:   1,
+		},
+		{
+			name:   "values",
+			values: [][]float64{{3, -1, 2}},
+			lo:     -1,
+			hi:     3,
+		},
+		{
+			name:   "series",
+			values: [][]float64{{1, 2}, {-4}, {}, {8}},
+			lo:     -4,
+			hi:     8,
+		},
+		{
+			name:   "nan and inf",
+			values: [][]float64{{nan, 1, inf, 2, -inf}},
+			lo:     1,
+			hi:     2,
+		},
+		{
+			name:   "only nan and inf",
+			values: [][]float64{{nan, inf, -inf}},
+			lo:     0,
+			hi:     1,
+		},
+		{
+			name:   "all equal",
+			values: [][]float64{{2, 2, 2}},
+			lo:     1.5,
+			hi:     2.5,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lo, hi := plotRange(tc.values...)
+			if lo != tc.lo || hi != tc.hi {
+				t.Errorf("plotRange() = (%v, %v), want (%v, %v)", lo, hi, tc.lo, tc.hi)
+			}
+		})
+	}
+}
+
+func TestNiceStep(t *testing.T) {
+	testCases := []struct {
+		span float64
+		n    int
+		want float64
+	}{
+		{span: 4, n: 4, want: 1},
+		{span: 10, n: 4, want: 2},
+		{span: 20, n: 4, want: 5},
+		{span: 35, n: 4, want: 10},
+		{span: 0.4, n: 4, want: 0.1},
+		{span: 4000, n: 4, want: 1000},
+		{span: 0, n: 4, want: 1},
+		{span: -1, n: 4, want: 1},
+		{span: math.NaN(), n: 4, want: 1},
+		{span: math.Inf(1), n: 4, want: 1},
+	}
+	for _, tc := range testCases {
+		if got := niceStep(tc.span, tc.n); math.Abs(got-tc.want) > tc.want*1e-9 {
+			t.Errorf("niceStep(%v, %d) = %v, want %v", tc.span, tc.n, got, tc.want)
+		}
+	}
+}
+
+func TestAppendGridValues(t *testing.T) {
+	testCases := []struct {
+		name   string
+		lo, hi float64
+		want   []float64
+		step   float64
+	}{
+		{
+			name: "integers",
+			lo:   0,
+			hi:   4,
+			want: []float64{0, 1, 2, 3, 4},
+			step: 1,
+		},
+		{
+			name: "not aligned",
+			lo:   -3,
+			hi:   17,
+			want: []float64{0, 5, 10, 15},
+			step: 5,
+		},
+		{
+			name: "negative",
+			lo:   -10,
+			hi:   -1,
+			want: []float64{-10, -8, -6, -4, -2},
+			step: 2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			values, step := appendGridValues(nil, tc.lo, tc.hi)
+			if !slices.Equal(values, tc.want) || step != tc.step {
+				t.Errorf("appendGridValues(nil, %v, %v) = (%v, %v), want (%v, %v)", tc.lo, tc.hi, values, step, tc.want, tc.step)
+			}
+		})
+	}
+
+	// the number of the values is limited even if the step is too small for the precision.
+	values, _ := appendGridValues(nil, 1e20, 1e20+1e6)
+	if len(values) > plotMaxGridLines {
+		t.Errorf("appendGridValues with a tiny step: got %d values, want at most %d", len(values), plotMaxGridLines)
+	}
+}
+
+func TestPlotColumnRange(t *testing.T) {
+	testCases := []struct {
+		name   string
+		values []float64
+		x, w   int
+		lo, hi float64
+		ok     bool
+	}{
+		{
+			name:   "one value",
+			values: []float64{3},
+			x:      0,
+			w:      10,
+			lo:     3,
+			hi:     3,
+			ok:     true,
+		},
+		{
+			name:   "one value in the last column",
+			values: []float64{3},
+			x:      9,
+			w:      10,
+			lo:     3,
+			hi:     3,
+			ok:     true,
+		},
+		{
+			name:   "spike between columns",
+			values: []float64{0, 0, 9, 0, 0},
+			x:      1,
+			w:      3,
+			lo:     0,
+			hi:     9,
+			ok:     true,
+		},
+		{
+			name:   "nan",
+			values: []float64{math.NaN()},
+			x:      0,
+			w:      10,
+			ok:     false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lo, hi, ok := plotColumnRange(tc.values, tc.x, tc.w)
+			if ok != tc.ok {
+				t.Fatalf("plotColumnRange() ok = %t, want %t", ok, tc.ok)
+			}
+			if ok && (lo != tc.lo || hi != tc.hi) {
+				t.Errorf("plotColumnRange() = (%v, %v), want (%v, %v)", lo, hi, tc.lo, tc.hi)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"math"
+)
+
+// The input recording format
+//
+// A recording is a header followed by frames until the end of the stream.
+// All the integers are little endian.
+//
+// The header:
+//
+//	magic   [4]byte  "DUIR"
+//	version uint16   1
+//
+// Each frame, encoded with the varint encoding of encoding/binary
+// (signed for varint and unsigned for uvarint):
+//
+//	cursor X, cursor Y   varint
+//	mouse buttons        uvarint, bit 0: left, bit 1: right, bit 2: middle
+//	scroll X, scroll Y   varint, in pixels
+//	keys                 uvarint, the pressed keys (see below)
+//	navigation           uvarint, the gamepad navigation actions pressed in the frame
+//	                     bit 0: next, bit 1: previous, bit 2: activate, bit 3: cancel,
+//	                     bit 4: next window, bit 5: previous window,
+//	                     bit 6: left, bit 7: right, bit 8: up, bit 9: down
+//	flags                uvarint, bit 0: touch is active
+//	text length          uvarint, in bytes
+//	text                 UTF-8 bytes, the characters typed in the frame
+//
+// The bits of the keys are:
+//
+//	 0: Shift       1: Control     2: Alt         3: Backspace
+//	 4: Enter       5: Tab         6: Space       7: Delete
+//	 8: ArrowLeft   9: ArrowRight 10: ArrowUp    11: ArrowDown
+//	12: Home       13: End        14: Escape     15: PageUp
+//	16: PageDown   17: Meta       18: A          19: C
+//	20: V          21: X
+//
+// The keys and the mouse buttons are states, not events.
+// Key repeating is not recorded but reproduced from the states.
+
+const (
+	inputRecordMagic   = "DUIR"
+	inputRecordVersion = 1
+
+	inputRecordFlagTouch = 1 << 0
+
+	// inputRecordMaxText is the maximum length of the text of a frame in bytes.
+	// A longer text is treated as a corrupt recording rather than allocated.
+	inputRecordMaxText = 1 << 16
+)
+
+// InputRecorder records the input of DebugUI frame by frame.
+//
+// Set an InputRecorder to DebugUI with SetInputRecorder.
+type InputRecorder struct {
+	w   *bufio.Writer
+	buf []byte
+	err error
+}
+
+// NewInputRecorder creates a new InputRecorder writing to w, and writes the header.
+func NewInputRecorder(w io.Writer) (*InputRecorder, error) {
+	r := &InputRecorder{
+		w: bufio.NewWriter(w),
+	}
+	r.buf = append(r.buf, inputRecordMagic...)
+	r.buf = binary.LittleEndian.AppendUint16(r.buf, inputRecordVersion)
+	if _, err := r.w.Write(r.buf); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *InputRecorder) record(f *inputFrame) {
+	if r.err != nil {
+		return
+	}
+	b := r.buf[:0]
+	b = binary.AppendVarint(b, int64(f.cursor.X))
+	b = binary.AppendVarint(b, int64(f.cursor.Y))
+	b = binary.AppendUvarint(b, uint64(f.mouseDown))
+	b = binary.AppendVarint(b, int64(f.scroll.X))
+	b = binary.AppendVarint(b, int64(f.scroll.Y))
+	b = binary.AppendUvarint(b, uint64(f.keyDown))
+	b = binary.AppendUvarint(b, uint64(f.nav))
+	var flags uint64
+	if f.touch {
+		flags |= inputRecordFlagTouch
+	}
+	b = binary.AppendUvarint(b, flags)
+	str := string(f.chars)
+	b = binary.AppendUvarint(b, uint64(len(str)))
+	b = append(b, str...)
+	r.buf = b
+	if _, err := r.w.Write(b); err != nil {
+		r.err = err
+	}
+}
+
+// Flush writes the buffered frames to the underlying writer.
+// Flush returns the first error that happened while recording, if any.
+func (r *InputRecorder) Flush() error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.w.Flush()
+}
+
+// InputPlayer replays input recorded by InputRecorder.
+//
+// Set an InputPlayer to DebugUI with SetInputPlayer.
+// While an InputPlayer is set, the input source is not read at all, so a recording can be replayed headlessly.
+type InputPlayer struct {
+	r      *bufio.Reader
+	cursor image.Point
+	done   bool
+	err    error
+}
+
+// NewInputPlayer creates a new InputPlayer reading from r, and reads the header.
+func NewInputPlayer(r io.Reader) (*InputPlayer, error) {
+	p := &InputPlayer{
+		r: bufio.NewReader(r),
+	}
+	var header [len(inputRecordMagic) + 2]byte
+	if _, err := io.ReadFull(p.r, header[:]); err != nil {
+		return nil, err
+	}
+	if string(header[:len(inputRecordMagic)]) != inputRecordMagic {
+		return nil, errors.New("debugui: invalid input recording")
+	}
+	if v := binary.LittleEndian.Uint16(header[len(inputRecordMagic):]); v != inputRecordVersion {
+		return nil, fmt.Errorf("debugui: unsupported input recording version: %d", v)
+	}
+	return p, nil
+}
+
+// Done reports whether all the frames have been replayed.
+// After that, the InputPlayer keeps replaying frames without any input.
+func (p *InputPlayer) Done() bool {
+	return p.done
+}
+
+// Err returns the error that stopped replaying, if any.
+func (p *InputPlayer) Err() error {
+	return p.err
+}
+
+func (c *Context) setInputPlayer(player *InputPlayer) {
+	if player != nil {
+		// the replayed characters are inserted without the IME, so the text fields stop taking the IME input.
+		for _, f := range c.textFields {
+			f.Blur()
+		}
+	}
+	c.player = player
+}
+
+func (p *InputPlayer) next(f *inputFrame) {
+	if !p.done {
+		if err := p.readFrame(f); err != nil {
+			p.done = true
+			if err != io.EOF {
+				p.err = err
+			}
+		}
+	}
+	if p.done {
+		f.cursor = p.cursor
+		f.mouseDown = 0
+		f.scroll = image.Point{}
+		f.keyDown = 0
+		f.nav = 0
+		f.touch = false
+		f.chars = f.chars[:0]
+		return
+	}
+	p.cursor = f.cursor
+}
+
+func (p *InputPlayer) readFrame(f *inputFrame) error {
+	// a clean end of the stream is at the start of a frame.
+	if _, err := p.r.Peek(1); err != nil {
+		return err
+	}
+
+	r := frameReader{r: p.r}
+	cursor := image.Pt(r.varint(), r.varint())
+	mouseDown := r.uvarint()
+	scroll := image.Pt(r.varint(), r.varint())
+	keyDown := r.uvarint()
+	nav := r.uvarint()
+	flags := r.uvarint()
+	n := r.uvarint()
+	if r.err == nil && n > inputRecordMaxText {
+		r.err = fmt.Errorf("debugui: invalid input recording: the text of a frame is too long: %d bytes", n)
+	}
+	var text []byte
+	if r.err == nil {
+		text = make([]byte, n)
+		_, r.err = io.ReadFull(p.r, text)
+	}
+	if r.err != nil {
+		if r.err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return r.err
+	}
+
+	f.cursor = cursor
+	f.mouseDown = mouseDown
+	f.scroll = scroll
+	f.keyDown = keyDown
+	f.nav = nav
+	f.touch = (flags & inputRecordFlagTouch) != 0
+	f.chars = append(f.chars[:0], []rune(string(text))...)
+	return nil
+}
+
+// frameReader reads integers of a frame and keeps the first error.
+type frameReader struct {
+	r   *bufio.Reader
+	err error
+}
+
+func (r *frameReader) varint() int {
+	if r.err != nil {
+		return 0
+	}
+	v, err := binary.ReadVarint(r.r)
+	r.err = err
+	return int(v)
+}
+
+func (r *frameReader) uvarint() int {
+	if r.err != nil {
+		return 0
+	}
+	v, err := binary.ReadUvarint(r.r)
+	if err == nil && v > math.MaxInt {
+		err = fmt.Errorf("debugui: invalid input recording: too large value: %d", v)
+	}
+	r.err = err
+	return int(v)
+}
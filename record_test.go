@@ -0,0 +1,218 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// recordedUI is a UI with a text box and a checkbox that records its state.
+type recordedUI struct {
+	text    string
+	checked bool
+
+	textBoxRect  image.Rectangle
+	checkboxRect image.Rectangle
+}
+
+func (u *recordedUI) update(ctx *Context) {
+	ctx.Window("Window", image.Rect(0, 0, 200, 200), func(res Response, layout Layout) {
+		ctx.SetLayoutRow([]int{-1}, 0)
+		ctx.TextBox(&u.text)
+		u.textBoxRect = ctx.lastRect
+		ctx.Checkbox("Check", &u.checked)
+		u.checkboxRect = ctx.lastRect
+	})
+}
+
+func TestInputRecordAndReplay(t *testing.T) {
+	var ui recordedUI
+	input := newTestInput()
+	d := newTestUI(input)
+	var rec bytes.Buffer
+	recorder, err := NewInputRecorder(&rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SetInputRecorder(recorder)
+
+	var snapshots []string
+	frame := func(f func()) {
+		f()
+		d.Update(ui.update)
+		var buf bytes.Buffer
+		if err := d.WriteCommandsJSON(&buf); err != nil {
+			t.Fatal(err)
+		}
+		snapshots = append(snapshots, buf.String())
+	}
+	frame(func() {})
+	frame(func() { input.cursor = ui.textBoxRect.Min.Add(image.Pt(2, 2)) })
+	frame(func() { input.buttons[ebiten.MouseButtonLeft] = true })
+	frame(func() { input.buttons[ebiten.MouseButtonLeft] = false })
+	frame(func() { input.chars = append(input.chars, []rune("héllo")...) })
+	frame(func() { input.keys[ebiten.KeyBackspace] = true })
+	for i := 0; i < keyRepeatDelay+keyRepeatInterval; i++ {
+		// the key repeats while it is held.
+		frame(func() {})
+	}
+	frame(func() { input.keys[ebiten.KeyBackspace] = false })
+	frame(func() { input.cursor = ui.checkboxRect.Min.Add(image.Pt(2, 2)) })
+	frame(func() { input.buttons[ebiten.MouseButtonLeft] = true })
+	frame(func() { input.buttons[ebiten.MouseButtonLeft] = false })
+	if err := recorder.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if ui.text != "hé" || !ui.checked {
+		t.Fatalf("recorded UI: got %q and %v, want %q and true", ui.text, ui.checked, "hé")
+	}
+
+	var replayed recordedUI
+	d = newTestUI(newTestInput())
+	player, err := NewInputPlayer(&rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SetInputPlayer(player)
+	for i, want := range snapshots {
+		d.Update(replayed.update)
+		var buf bytes.Buffer
+		if err := d.WriteCommandsJSON(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != want {
+			t.Fatalf("frame %d: got %s, want %s", i, got, want)
+		}
+	}
+	if replayed.text != ui.text || replayed.checked != ui.checked {
+		t.Errorf("replayed UI: got %q and %v, want %q and %v", replayed.text, replayed.checked, ui.text, ui.checked)
+	}
+	if player.Done() {
+		t.Errorf("Done() before the end of the recording = true, want false")
+	}
+	d.Update(replayed.update)
+	if !player.Done() {
+		t.Errorf("Done() after the end of the recording = false, want true")
+	}
+	if err := player.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+}
+
+func TestInputPlayerInvalidHeader(t *testing.T) {
+	testCases := []struct {
+		name   string
+		header []byte
+	}{
+		{
+			name:   "magic",
+			header: binary.LittleEndian.AppendUint16([]byte("DUIX"), inputRecordVersion),
+		},
+		{
+			name:   "version",
+			header: binary.LittleEndian.AppendUint16([]byte(inputRecordMagic), inputRecordVersion+1),
+		},
+		{
+			name:   "short",
+			header: []byte(inputRecordMagic),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewInputPlayer(bytes.NewReader(tc.header)); err == nil {
+				t.Errorf("NewInputPlayer() with an invalid header must return an error")
+			}
+		})
+	}
+}
+
+func TestInputPlayerInvalidFrame(t *testing.T) {
+	header := binary.LittleEndian.AppendUint16([]byte(inputRecordMagic), inputRecordVersion)
+	// fields appends the fields of a frame before the text length, with the cursor at (1, 2) and no other input.
+	fields := func(b []byte) []byte {
+		b = binary.AppendVarint(b, 1)  // cursor X
+		b = binary.AppendVarint(b, 2)  // cursor Y
+		b = binary.AppendUvarint(b, 0) // mouse buttons
+		b = binary.AppendVarint(b, 0)  // scroll X
+		b = binary.AppendVarint(b, 0)  // scroll Y
+		b = binary.AppendUvarint(b, 0) // keys
+		b = binary.AppendUvarint(b, 0) // navigation
+		b = binary.AppendUvarint(b, 0) // flags
+		return b
+	}
+	testCases := []struct {
+		name      string
+		frame     []byte
+		wantEOF   bool
+		wantError bool
+	}{
+		{
+			name:  "valid",
+			frame: append(binary.AppendUvarint(fields(nil), 2), "ab"...),
+		},
+		{
+			name:      "truncated frame",
+			frame:     binary.AppendVarint(nil, 1),
+			wantEOF:   true,
+			wantError: true,
+		},
+		{
+			name:      "truncated text",
+			frame:     append(binary.AppendUvarint(fields(nil), 5), "ab"...),
+			wantEOF:   true,
+			wantError: true,
+		},
+		{
+			name:      "missing text",
+			frame:     binary.AppendUvarint(fields(nil), 5),
+			wantEOF:   true,
+			wantError: true,
+		},
+		{
+			name:      "oversized text",
+			frame:     append(binary.AppendUvarint(fields(nil), inputRecordMaxText+1), "ab"...),
+			wantError: true,
+		},
+		{
+			name:      "overflowing length",
+			frame:     binary.AppendUvarint(fields(nil), math.MaxUint64),
+			wantError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			player, err := NewInputPlayer(bytes.NewReader(append(header[:len(header):len(header)], tc.frame...)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			d := newTestUI(newTestInput())
+			d.SetInputPlayer(player)
+			d.Update(func(ctx *Context) {})
+
+			err = player.Err()
+			if (err != nil) != tc.wantError {
+				t.Fatalf("Err() = %v, want error: %t", err, tc.wantError)
+			}
+			if got := errors.Is(err, io.ErrUnexpectedEOF); got != tc.wantEOF {
+				t.Errorf("Err() = %v, want io.ErrUnexpectedEOF: %t", err, tc.wantEOF)
+			}
+			if player.Done() != tc.wantError {
+				t.Errorf("Done() = %t, want %t", player.Done(), tc.wantError)
+			}
+			if !tc.wantError {
+				if got, want := d.ctx.mousePos, image.Pt(1, 2); got != want {
+					t.Errorf("cursor: got %v, want %v", got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// CommandType represents the type of a drawing command.
+type CommandType int
+
+const (
+	// CommandClip sets the clipping rectangle for the following commands.
+	CommandClip CommandType = commandClip
+
+	// CommandRect fills a rectangle.
+	CommandRect CommandType = commandRect
+
+	// CommandText draws text.
+	CommandText CommandType = commandText
+
+	// CommandIcon draws an icon centered in a rectangle.
+	CommandIcon CommandType = commandIcon
+
+	// CommandDraw calls a custom drawing function registered by Context.Draw.
+	CommandDraw CommandType = commandDraw
+
+	// CommandRoundedRect fills a rectangle with rounded corners.
+	// A renderer that cannot draw rounded corners can fill the rectangle instead.
+	CommandRoundedRect CommandType = commandRoundedRect
+
+	// CommandOutline draws a border inside a rectangle with rounded corners.
+	// A renderer that cannot draw rounded corners can draw the border of the rectangle instead.
+	CommandOutline CommandType = commandOutline
+
+	// CommandShadow draws a soft shadow around a rectangle with rounded corners.
+	// A renderer that cannot draw shadows can skip it.
+	CommandShadow CommandType = commandShadow
+)
+
+// Command is a drawing command in the finished command list.
+//
+// A Command is valid until the next Update.
+type Command struct {
+	cmd *command
+}
+
+// Type returns the type of the command.
+func (c Command) Type() CommandType {
+	return CommandType(c.cmd.typ)
+}
+
+// Rect returns the rectangle of a CommandClip, CommandRect, CommandIcon, CommandRoundedRect, CommandOutline or CommandShadow command.
+func (c Command) Rect() image.Rectangle {
+	switch c.cmd.typ {
+	case commandClip, commandRect, commandIcon, commandRoundedRect, commandOutline, commandShadow:
+		return c.cmd.rect
+	}
+	return image.Rectangle{}
+}
+
+// Color returns the color of a CommandRect, CommandText, CommandIcon, CommandRoundedRect, CommandOutline or CommandShadow command.
+//
+// For a CommandDraw command, Color returns the color scale to apply to the drawing, e.g. for a translucent window.
+// The color scale is opaque white when the drawing is drawn as it is.
+//
+// The color is premultiplied. Color returns a zero color for the other commands.
+func (c Command) Color() color.RGBA {
+	return c.cmd.color
+}
+
+// Text returns the text of a CommandText command.
+func (c Command) Text() string {
+	return c.cmd.str
+}
+
+// Pos returns the top-left position of the text of a CommandText command.
+func (c Command) Pos() image.Point {
+	if c.cmd.typ != commandText {
+		return image.Point{}
+	}
+	return c.cmd.rect.Min
+}
+
+// Face returns the font face of a CommandText command.
+func (c Command) Face() text.Face {
+	if c.cmd.face == nil {
+		return defaultFace
+	}
+	return c.cmd.face
+}
+
+// Icon returns the icon of a CommandIcon command.
+func (c Command) Icon() Icon {
+	return c.cmd.icon
+}
+
+// Radius returns the corner radius of a CommandRoundedRect, CommandOutline or CommandShadow command.
+func (c Command) Radius() int {
+	return c.cmd.radius
+}
+
+// Width returns the border width of a CommandOutline command, or the size of a CommandShadow command.
+func (c Command) Width() int {
+	return c.cmd.width
+}
+
+// DrawFunc returns the custom drawing function of a CommandDraw command.
+func (c Command) DrawFunc() func(screen *ebiten.Image) {
+	return c.cmd.f
+}
+
+// CommandIterator iterates over the finished command list in drawing order.
+// The jumps between windows are already resolved, so the windows come in z-order.
+//
+//	it := debugUI.Commands()
+//	for it.Next() {
+//		cmd := it.Command()
+//		// ...
+//	}
+type CommandIterator struct {
+	ctx *Context
+	cmd *command
+
+	// ranged reports whether the iterator is over the part of the list from start until end.
+	ranged bool
+	start  int
+	end    int
+}
+
+// Next advances the iterator to the next command and reports whether there is one.
+func (it *CommandIterator) Next() bool {
+	if it.ranged {
+		return it.ctx.nextCommandInRange(&it.cmd, it.start, it.end)
+	}
+	return it.ctx.nextCommand(&it.cmd)
+}
+
+// Command returns the current command.
+func (it *CommandIterator) Command() Command {
+	return Command{cmd: it.cmd}
+}
+
+// Renderer renders the finished command list.
+//
+// A Renderer should ignore the command types it doesn't know so that it keeps working when types are added.
+type Renderer interface {
+	// Render renders the commands.
+	Render(commands *CommandIterator)
+}
+
+func (c *Context) commands() *CommandIterator {
+	return &CommandIterator{ctx: c}
+}
+
+// rootCommands returns an iterator over the commands of the root container, excluding nested root containers.
+// The iterator is reused and valid until the next rootCommands or overlayCommands call.
+func (c *Context) rootCommands(cnt *container) *CommandIterator {
+	c.commandIter = CommandIterator{
+		ctx:    c,
+		ranged: true,
+		start:  cnt.headIdx + 1,
+		end:    cnt.tailIdx,
+	}
+	return &c.commandIter
+}
+
+// overlayCommands returns an iterator over the commands drawn on top of all the root containers.
+// The iterator is reused and valid until the next rootCommands or overlayCommands call.
+func (c *Context) overlayCommands() *CommandIterator {
+	c.commandIter = CommandIterator{
+		ctx:    c,
+		ranged: true,
+		start:  c.overlayIdx,
+		end:    len(c.commandList),
+	}
+	return &c.commandIter
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// bodyTexts returns the texts of the CommandText commands of the iterator that end with "-body".
+func bodyTexts(it *CommandIterator) []string {
+	var texts []string
+	for it.Next() {
+		if cmd := it.Command(); cmd.Type() == CommandText && strings.HasSuffix(cmd.Text(), "-body") {
+			texts = append(texts, cmd.Text())
+		}
+	}
+	return texts
+}
+
+func TestCommandIteratorJumps(t *testing.T) {
+	input := newTestInput()
+	d := newTestUI(input)
+	windows := []struct {
+		label string
+		rect  image.Rectangle
+	}{
+		{label: "a", rect: image.Rect(10, 10, 110, 110)},
+		{label: "b", rect: image.Rect(60, 60, 160, 160)},
+		{label: "c", rect: image.Rect(200, 10, 300, 110)},
+	}
+	f := func(ctx *Context) {
+		for _, w := range windows {
+			ctx.Window(w.label, w.rect, func(res Response, layout Layout) {
+				ctx.Text(w.label + "-body")
+			})
+		}
+	}
+	bodies := func() []string {
+		return bodyTexts(d.Commands())
+	}
+
+	d.Update(f)
+	if got, want := bodies(), []string{"a-body", "b-body", "c-body"}; !slices.Equal(got, want) {
+		t.Errorf("the commands in the initial order: got %v, want %v", got, want)
+	}
+
+	// bringing a window to the front changes only the jumps, and the commands follow the z-order.
+	click(d, input, image.Pt(20, 100), f)
+	if got, want := bodies(), []string{"b-body", "c-body", "a-body"}; !slices.Equal(got, want) {
+		t.Errorf("the commands after the first window is brought to the front: got %v, want %v", got, want)
+	}
+
+	// every command but the jumps is visited exactly once.
+	var visited []int
+	for it := d.Commands(); it.Next(); {
+		visited = append(visited, it.cmd.idx)
+	}
+	var want []int
+	for i, cmd := range d.ctx.commandList {
+		if cmd.typ != commandJump {
+			want = append(want, i)
+		}
+	}
+	slices.Sort(visited)
+	if !slices.Equal(visited, want) {
+		t.Errorf("the visited commands: got %v, want %v", visited, want)
+	}
+
+	// the commands of a root container don't include the other root containers.
+	for _, cnt := range d.ctx.rootList {
+		if got := bodyTexts(d.ctx.rootCommands(cnt)); len(got) != 1 {
+			t.Errorf("the bodies in the commands of a root container: got %v, want one", got)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// updateScale updates the device scale factor used when the scale is not set explicitly.
+//
+// The monitor is queried regardless of the input source unless it is disabled by Options.NoDeviceScale.
+func (c *Context) updateScale() {
+	c.deviceScale = 1
+	if c.noDeviceScale {
+		return
+	}
+	if m := ebiten.Monitor(); m != nil {
+		c.deviceScale = m.DeviceScaleFactor()
+	}
+}
+
+// uiScale returns the scale factor from the UI coordinates to the screen pixels.
+func (c *Context) uiScale() float64 {
+	if c.scale > 0 {
+		return c.scale
+	}
+	if c.deviceScale > 0 {
+		return c.deviceScale
+	}
+	return 1
+}
+
+// scalePoint scales p by scale and rounds it to the nearest pixel.
+func scalePoint(p image.Point, scale float64) image.Point {
+	return image.Pt(int(math.Round(float64(p.X)*scale)), int(math.Round(float64(p.Y)*scale)))
+}
+
+// scaleRect scales r by scale and rounds it to the nearest pixels.
+// Adjacent rectangles stay adjacent after scaling.
+func scaleRect(r image.Rectangle, scale float64) image.Rectangle {
+	return image.Rectangle{Min: scalePoint(r.Min, scale), Max: scalePoint(r.Max, scale)}
+}
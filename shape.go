@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// appendRoundedRectPath appends a rectangle with rounded corners to the path.
+func appendRoundedRectPath(path *vector.Path, x0, y0, x1, y1, radius float32) {
+	radius = max(0, min(radius, (x1-x0)/2, (y1-y0)/2))
+	path.MoveTo(x0+radius, y0)
+	path.LineTo(x1-radius, y0)
+	path.ArcTo(x1, y0, x1, y0+radius, radius)
+	path.LineTo(x1, y1-radius)
+	path.ArcTo(x1, y1, x1-radius, y1, radius)
+	path.LineTo(x0+radius, y1)
+	path.ArcTo(x0, y1, x0, y1-radius, radius)
+	path.LineTo(x0, y0+radius)
+	path.ArcTo(x0, y0, x0+radius, y0, radius)
+	path.Close()
+}
+
+// setVertexColor sets the source to the white region of the atlas and the color multiplied by alpha to the vertices.
+func setVertexColor(vertices []ebiten.Vertex, clr color.RGBA, alpha float32) {
+	cr, cg, cb, ca := float32(clr.R)/0xff*alpha, float32(clr.G)/0xff*alpha, float32(clr.B)/0xff*alpha, float32(clr.A)/0xff*alpha
+	for i := range vertices {
+		v := &vertices[i]
+		v.SrcX = float32(atlasWhite.Min.X) + 0.5
+		v.SrcY = float32(atlasWhite.Min.Y) + 0.5
+		v.ColorR = cr
+		v.ColorG = cg
+		v.ColorB = cb
+		v.ColorA = ca
+	}
+}
+
+// shapeRect returns the rectangle in the pixels of the target.
+// Unlike scaleRect, the result is not rounded so that the shapes are smooth.
+func (r *EbitenRenderer) shapeRect(rect image.Rectangle, scale float64) (x0, y0, x1, y1 float32) {
+	rect = rect.Sub(r.offset)
+	s := float32(scale)
+	return float32(rect.Min.X) * s, float32(rect.Min.Y) * s, float32(rect.Max.X) * s, float32(rect.Max.Y) * s
+}
+
+// drawShape draws a CommandRoundedRect, CommandOutline or CommandShadow command with anti-aliased paths.
+func (r *EbitenRenderer) drawShape(target *ebiten.Image, cmd Command, scale float64) {
+	x0, y0, x1, y1 := r.shapeRect(cmd.Rect(), scale)
+	radius := float32(cmd.Radius()) * float32(scale)
+	width := float32(cmd.Width()) * float32(scale)
+	vs, is := r.shapeVertices[:0], r.shapeIndices[:0]
+
+	switch cmd.Type() {
+	case CommandRoundedRect:
+		var path vector.Path
+		appendRoundedRectPath(&path, x0, y0, x1, y1, radius)
+		vs, is = path.AppendVerticesAndIndicesForFilling(vs, is)
+		setVertexColor(vs, cmd.Color(), 1)
+	case CommandOutline:
+		// the stroke is centered on the path, so the path is inset by the half of the width.
+		var path vector.Path
+		d := width / 2
+		appendRoundedRectPath(&path, x0+d, y0+d, x1-d, y1-d, radius-d)
+		vs, is = path.AppendVerticesAndIndicesForStroke(vs, is, &vector.StrokeOptions{Width: width})
+		setVertexColor(vs, cmd.Color(), 1)
+	case CommandShadow:
+		// the shadow is drawn as rings around the rectangle fading out.
+		for i := 0; i < int(width); i++ {
+			var path vector.Path
+			d := float32(i) + 0.5
+			appendRoundedRectPath(&path, x0-d, y0-d, x1+d, y1+d, radius+d)
+			n := len(vs)
+			vs, is = path.AppendVerticesAndIndicesForStroke(vs, is, &vector.StrokeOptions{Width: 1})
+			a := (width - float32(i)) / (width + 1)
+			setVertexColor(vs[n:], cmd.Color(), a*a)
+		}
+	}
+
+	if len(is) > 0 {
+		img, _ := atlas()
+		op := &ebiten.DrawTrianglesOptions{}
+		op.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
+		op.AntiAlias = true
+		// the triangles of a stroke overlap, so the non-zero rule is used not to blend them twice.
+		op.FillRule = ebiten.FillRuleNonZero
+		target.DrawTriangles(vs, is, img, op)
+	}
+	r.shapeVertices, r.shapeIndices = vs, is
+}
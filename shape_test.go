@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"image/color"
+	"slices"
+	"testing"
+)
+
+func TestShapeCommands(t *testing.T) {
+	clr := color.RGBA{R: 0x80, A: 0xff}
+	testCases := []struct {
+		name  string
+		draw  func(c *Context, body image.Rectangle)
+		types []CommandType
+		rect  image.Rectangle
+		// radius and width are the values of the shape command.
+		radius int
+		width  int
+	}{
+		{
+			name: "rounded rect without radius",
+			draw: func(c *Context, body image.Rectangle) {
+				c.drawRoundedRect(image.Rect(0, 0, 20, 20).Add(body.Min), 0, clr)
+			},
+			types: []CommandType{CommandRect},
+		},
+		{
+			name: "rounded rect",
+			draw: func(c *Context, body image.Rectangle) {
+				c.drawRoundedRect(image.Rect(0, 0, 20, 20).Add(body.Min), 4, clr)
+			},
+			types:  []CommandType{CommandRoundedRect},
+			rect:   image.Rect(0, 0, 20, 20),
+			radius: 4,
+		},
+		{
+			name: "rounded rect clipped partly",
+			draw: func(c *Context, body image.Rectangle) {
+				c.drawRoundedRect(image.Rect(-10, 0, 20, 20).Add(body.Min), 4, clr)
+			},
+			types:  []CommandType{CommandClip, CommandRoundedRect, CommandClip},
+			rect:   image.Rect(-10, 0, 20, 20),
+			radius: 4,
+		},
+		{
+			name: "rounded rect clipped entirely",
+			draw: func(c *Context, body image.Rectangle) {
+				c.drawRoundedRect(image.Rect(-30, 0, -10, 20).Add(body.Min), 4, clr)
+			},
+		},
+		{
+			name: "outline without width",
+			draw: func(c *Context, body image.Rectangle) {
+				c.drawOutline(image.Rect(0, 0, 20, 20).Add(body.Min), 4, 0, clr)
+			},
+		},
+		{
+			name: "outline without radius",
+			draw: func(c *Context, body image.Rectangle) {
+				c.drawOutline(image.Rect(0, 0, 20, 20).Add(body.Min), 0, 2, clr)
+			},
+			types: []CommandType{CommandRect, CommandRect, CommandRect, CommandRect},
+		},
+		{
+			name: "outline",
+			draw: func(c *Context, body image.Rectangle) {
+				c.drawOutline(image.Rect(0, 0, 20, 20).Add(body.Min), 4, 2, clr)
+			},
+			types:  []CommandType{CommandOutline},
+			rect:   image.Rect(0, 0, 20, 20),
+			radius: 4,
+			width:  2,
+		},
+		{
+			name: "shadow without size",
+			draw: func(c *Context, body image.Rectangle) {
+				c.drawShadow(image.Rect(10, 10, 30, 30).Add(body.Min), 4, 0, clr)
+			},
+		},
+		{
+			name: "shadow without color",
+			draw: func(c *Context, body image.Rectangle) {
+				c.drawShadow(image.Rect(10, 10, 30, 30).Add(body.Min), 4, 6, color.RGBA{})
+			},
+		},
+		{
+			name: "shadow",
+			draw: func(c *Context, body image.Rectangle) {
+				c.drawShadow(image.Rect(10, 10, 30, 30).Add(body.Min), 4, 6, clr)
+			},
+			types:  []CommandType{CommandShadow},
+			rect:   image.Rect(10, 10, 30, 30),
+			radius: 4,
+			width:  6,
+		},
+		{
+			name: "shadow clipped partly",
+			draw: func(c *Context, body image.Rectangle) {
+				// the rectangle is inside the body, but its shadow is not.
+				c.drawShadow(image.Rect(2, 10, 30, 30).Add(body.Min), 4, 6, clr)
+			},
+			types:  []CommandType{CommandClip, CommandShadow, CommandClip},
+			rect:   image.Rect(2, 10, 30, 30),
+			radius: 4,
+			width:  6,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newTestUI(newTestInput())
+			var cmds []Command
+			var body image.Rectangle
+			d.Update(func(ctx *Context) {
+				ctx.Window("Window", image.Rect(10, 10, 210, 210), func(res Response, layout Layout) {
+					body = layout.Body
+					start := len(ctx.commandList)
+					tc.draw(ctx, body)
+					for i := start; i < len(ctx.commandList); i++ {
+						cmds = append(cmds, Command{cmd: &ctx.commandList[i]})
+					}
+				})
+			})
+
+			var types []CommandType
+			for _, cmd := range cmds {
+				types = append(types, cmd.Type())
+			}
+			if !slices.Equal(types, tc.types) {
+				t.Fatalf("command types: got %v, want %v", types, tc.types)
+			}
+			for _, cmd := range cmds {
+				switch cmd.Type() {
+				case CommandRoundedRect, CommandOutline, CommandShadow:
+				default:
+					continue
+				}
+				if got, want := cmd.Rect(), tc.rect.Add(body.Min); got != want {
+					t.Errorf("Rect(): got %v, want %v", got, want)
+				}
+				if got := cmd.Radius(); got != tc.radius {
+					t.Errorf("Radius(): got %d, want %d", got, tc.radius)
+				}
+				if got := cmd.Width(); got != tc.width {
+					t.Errorf("Width(): got %d, want %d", got, tc.width)
+				}
+				if got := cmd.Color(); got != clr {
+					t.Errorf("Color(): got %v, want %v", got, clr)
+				}
+			}
+		})
+	}
+}
+
+func TestWindowBounds(t *testing.T) {
+	testCases := []struct {
+		name        string
+		borderWidth int
+		shadowSize  int
+		want        image.Rectangle
+	}{
+		{
+			name:        "border",
+			borderWidth: 1,
+			want:        image.Rect(9, 9, 111, 111),
+		},
+		{
+			name:        "no border",
+			borderWidth: 0,
+			want:        image.Rect(9, 9, 111, 111),
+		},
+		{
+			name:        "shadow",
+			borderWidth: 1,
+			shadowSize:  6,
+			want:        image.Rect(4, 4, 116, 116),
+		},
+		{
+			name:        "border wider than shadow",
+			borderWidth: 4,
+			shadowSize:  2,
+			want:        image.Rect(6, 6, 114, 114),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newTestUI(newTestInput())
+			d.SetBorderWidth(tc.borderWidth)
+			d.SetShadowSize(tc.shadowSize)
+			d.Update(func(ctx *Context) {
+				ctx.Window("Window", image.Rect(10, 10, 110, 110), func(res Response, layout Layout) {})
+			})
+			if got := d.ctx.windowBounds(d.ctx.rootList[0]); got != tc.want {
+				t.Errorf("windowBounds(): got %v, want %v", got, tc.want)
+			}
+
+			// the shadow is drawn within the bounds.
+			var shadows int
+			for it := d.Commands(); it.Next(); {
+				if cmd := it.Command(); cmd.Type() == CommandShadow {
+					shadows++
+					if r := cmd.Rect().Inset(-cmd.Width()); !r.In(tc.want) {
+						t.Errorf("the shadow %v is out of the bounds %v", r, tc.want)
+					}
+				}
+			}
+			if want := min(tc.shadowSize, 1); shadows != want {
+				t.Errorf("the number of the shadows: got %d, want %d", shadows, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2/exp/textinput"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// textField is the retained state of a text box.
+type textField struct {
+	textinput.Field
+
+	// caretAtStart reports whether the caret is at the start of the selection.
+	// The other end of the selection is the anchor, which doesn't move when the selection is extended.
+	caretAtStart bool
+
+	// scrollX is the horizontal scroll offset in pixels to keep the caret visible.
+	scrollX int
+}
+
+func (f *textField) caret() int {
+	start, end := f.Selection()
+	if f.caretAtStart {
+		return start
+	}
+	return end
+}
+
+func (f *textField) anchor() int {
+	start, end := f.Selection()
+	if f.caretAtStart {
+		return end
+	}
+	return start
+}
+
+// setCaret moves the caret. If extend is true, the selection is extended from the anchor to the caret.
+func (f *textField) setCaret(caret int, extend bool) {
+	anchor := caret
+	if extend {
+		anchor = f.anchor()
+	}
+	f.SetSelection(min(anchor, caret), max(anchor, caret))
+	f.caretAtStart = caret < anchor
+}
+
+func (c *Context) textField(id ID) *textField {
+	if id == 0 {
+		return nil
+	}
+	if _, ok := c.textFields[id]; !ok {
+		if c.textFields == nil {
+			c.textFields = make(map[ID]*textField)
+		}
+		c.textFields[id] = &textField{}
+	}
+	return c.textFields[id]
+}
+
+// handleCaretKeys moves the caret with the arrow, Home and End keys.
+// With Shift, the selection is extended.
+func (c *Context) handleCaretKeys(f *textField) {
+	text := f.Text()
+	start, end := f.Selection()
+	caret := f.caret()
+	extend := (c.keyDown & keyShift) != 0
+
+	switch {
+	case (c.keyPressed & keyArrowLeft) != 0:
+		if start != end && !extend {
+			f.setCaret(start, false)
+			return
+		}
+		if caret > 0 {
+			_, size := utf8.DecodeLastRuneInString(text[:caret])
+			caret -= size
+		}
+	case (c.keyPressed & keyArrowRight) != 0:
+		if start != end && !extend {
+			f.setCaret(end, false)
+			return
+		}
+		if caret < len(text) {
+			_, size := utf8.DecodeRuneInString(text[caret:])
+			caret += size
+		}
+	case (c.keyPressed & keyHome) != 0:
+		caret = 0
+	case (c.keyPressed & keyEnd) != 0:
+		caret = len(text)
+	default:
+		return
+	}
+	f.setCaret(caret, extend)
+}
+
+// textIndexAt returns the byte index of the rune boundary in str closest to x,
+// where x is relative to the start of the text.
+// The text is measured once, adding up the advances of the runes.
+func (c *Context) textIndexAt(str string, x int) int {
+	if x <= 0 {
+		return 0
+	}
+	face := c.face()
+	fx := float64(x)
+	var w float64
+	for i := 0; i < len(str); {
+		_, size := utf8.DecodeRuneInString(str[i:])
+		next := w + text.Advance(str[i:i+size], face)
+		if fx < next {
+			if fx-w < next-fx {
+				return i
+			}
+			return i + size
+		}
+		w = next
+		i += size
+	}
+	return len(str)
+}
+
+// scrollToCaret updates the horizontal scroll so that the caret at caretX is visible in the given width.
+func (f *textField) scrollToCaret(caretX, textWidth, width int) {
+	if caretX-f.scrollX > width {
+		f.scrollX = caretX - width
+	}
+	if caretX-f.scrollX < 0 {
+		f.scrollX = caretX
+	}
+	f.scrollX = clamp(f.scrollX, 0, max(0, textWidth+1-width))
+}
@@ -0,0 +1,377 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTextFieldSetCaret(t *testing.T) {
+	type step struct {
+		caret  int
+		extend bool
+	}
+	testCases := []struct {
+		name       string
+		steps      []step
+		wantStart  int
+		wantEnd    int
+		wantCaret  int
+		wantAnchor int
+	}{
+		{
+			name:       "move",
+			steps:      []step{{2, false}, {4, false}},
+			wantStart:  4,
+			wantEnd:    4,
+			wantCaret:  4,
+			wantAnchor: 4,
+		},
+		{
+			name:       "extend forward",
+			steps:      []step{{1, false}, {4, true}},
+			wantStart:  1,
+			wantEnd:    4,
+			wantCaret:  4,
+			wantAnchor: 1,
+		},
+		{
+			name:       "extend backward",
+			steps:      []step{{4, false}, {1, true}},
+			wantStart:  1,
+			wantEnd:    4,
+			wantCaret:  1,
+			wantAnchor: 4,
+		},
+		{
+			name:       "extend across the anchor",
+			steps:      []step{{3, false}, {1, true}, {5, true}},
+			wantStart:  3,
+			wantEnd:    5,
+			wantCaret:  5,
+			wantAnchor: 3,
+		},
+		{
+			name:       "collapse",
+			steps:      []step{{1, false}, {4, true}, {2, false}},
+			wantStart:  2,
+			wantEnd:    2,
+			wantCaret:  2,
+			wantAnchor: 2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var f textField
+			f.SetTextAndSelection("hello", 0, 0)
+			for _, s := range tc.steps {
+				f.setCaret(s.caret, s.extend)
+			}
+			if start, end := f.Selection(); start != tc.wantStart || end != tc.wantEnd {
+				t.Errorf("selection: got (%d, %d), want (%d, %d)", start, end, tc.wantStart, tc.wantEnd)
+			}
+			if got := f.caret(); got != tc.wantCaret {
+				t.Errorf("caret: got %d, want %d", got, tc.wantCaret)
+			}
+			if got := f.anchor(); got != tc.wantAnchor {
+				t.Errorf("anchor: got %d, want %d", got, tc.wantAnchor)
+			}
+		})
+	}
+}
+
+func TestHandleCaretKeys(t *testing.T) {
+	testCases := []struct {
+		name         string
+		text         string
+		start, end   int
+		caretAtStart bool
+		keyDown      int
+		keyPressed   int
+		wantStart    int
+		wantEnd      int
+		wantCaret    int
+	}{
+		{
+			name:       "left",
+			text:       "hello",
+			start:      3,
+			end:        3,
+			keyPressed: keyArrowLeft,
+			wantStart:  2,
+			wantEnd:    2,
+			wantCaret:  2,
+		},
+		{
+			name:       "left at the start",
+			text:       "hello",
+			keyPressed: keyArrowLeft,
+			wantStart:  0,
+			wantEnd:    0,
+			wantCaret:  0,
+		},
+		{
+			name:       "right",
+			text:       "hello",
+			start:      3,
+			end:        3,
+			keyPressed: keyArrowRight,
+			wantStart:  4,
+			wantEnd:    4,
+			wantCaret:  4,
+		},
+		{
+			name:       "right at the end",
+			text:       "hello",
+			start:      5,
+			end:        5,
+			keyPressed: keyArrowRight,
+			wantStart:  5,
+			wantEnd:    5,
+			wantCaret:  5,
+		},
+		{
+			name:       "right over a multibyte rune",
+			text:       "aあb",
+			start:      1,
+			end:        1,
+			keyPressed: keyArrowRight,
+			wantStart:  4,
+			wantEnd:    4,
+			wantCaret:  4,
+		},
+		{
+			name:       "left over a multibyte rune",
+			text:       "aあb",
+			start:      4,
+			end:        4,
+			keyPressed: keyArrowLeft,
+			wantStart:  1,
+			wantEnd:    1,
+			wantCaret:  1,
+		},
+		{
+			name:       "left collapses the selection",
+			text:       "hello",
+			start:      1,
+			end:        4,
+			keyPressed: keyArrowLeft,
+			wantStart:  1,
+			wantEnd:    1,
+			wantCaret:  1,
+		},
+		{
+			name:       "right collapses the selection",
+			text:       "hello",
+			start:      1,
+			end:        4,
+			keyPressed: keyArrowRight,
+			wantStart:  4,
+			wantEnd:    4,
+			wantCaret:  4,
+		},
+		{
+			name:       "home",
+			text:       "hello",
+			start:      3,
+			end:        3,
+			keyPressed: keyHome,
+			wantStart:  0,
+			wantEnd:    0,
+			wantCaret:  0,
+		},
+		{
+			name:       "end",
+			text:       "hello",
+			start:      1,
+			end:        3,
+			keyPressed: keyEnd,
+			wantStart:  5,
+			wantEnd:    5,
+			wantCaret:  5,
+		},
+		{
+			name:       "shift-left",
+			text:       "hello",
+			start:      3,
+			end:        3,
+			keyDown:    keyShift,
+			keyPressed: keyArrowLeft,
+			wantStart:  2,
+			wantEnd:    3,
+			wantCaret:  2,
+		},
+		{
+			name:       "shift-right extends from the anchor",
+			text:       "hello",
+			start:      1,
+			end:        3,
+			keyDown:    keyShift,
+			keyPressed: keyArrowRight,
+			wantStart:  1,
+			wantEnd:    4,
+			wantCaret:  4,
+		},
+		{
+			name:         "shift-right shrinks a backward selection",
+			text:         "hello",
+			start:        1,
+			end:          3,
+			caretAtStart: true,
+			keyDown:      keyShift,
+			keyPressed:   keyArrowRight,
+			wantStart:    2,
+			wantEnd:      3,
+			wantCaret:    2,
+		},
+		{
+			name:       "shift-home",
+			text:       "hello",
+			start:      3,
+			end:        3,
+			keyDown:    keyShift,
+			keyPressed: keyHome,
+			wantStart:  0,
+			wantEnd:    3,
+			wantCaret:  0,
+		},
+		{
+			name:         "shift-end over the anchor",
+			text:         "hello",
+			start:        1,
+			end:          3,
+			caretAtStart: true,
+			keyDown:      keyShift,
+			keyPressed:   keyEnd,
+			wantStart:    3,
+			wantEnd:      5,
+			wantCaret:    5,
+		},
+		{
+			name:       "other keys",
+			text:       "hello",
+			start:      1,
+			end:        3,
+			keyPressed: keyArrowUp,
+			wantStart:  1,
+			wantEnd:    3,
+			wantCaret:  3,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Context{
+				keyDown:    tc.keyDown,
+				keyPressed: tc.keyPressed,
+			}
+			var f textField
+			f.SetTextAndSelection(tc.text, tc.start, tc.end)
+			f.caretAtStart = tc.caretAtStart
+
+			c.handleCaretKeys(&f)
+			if start, end := f.Selection(); start != tc.wantStart || end != tc.wantEnd {
+				t.Errorf("selection: got (%d, %d), want (%d, %d)", start, end, tc.wantStart, tc.wantEnd)
+			}
+			if got := f.caret(); got != tc.wantCaret {
+				t.Errorf("caret: got %d, want %d", got, tc.wantCaret)
+			}
+		})
+	}
+}
+
+func TestTextFieldScrollToCaret(t *testing.T) {
+	testCases := []struct {
+		name      string
+		scrollX   int
+		caretX    int
+		textWidth int
+		width     int
+		want      int
+	}{
+		{
+			name:      "short text",
+			scrollX:   0,
+			caretX:    30,
+			textWidth: 50,
+			width:     100,
+			want:      0,
+		},
+		{
+			name:      "caret past the right edge",
+			scrollX:   0,
+			caretX:    150,
+			textWidth: 200,
+			width:     100,
+			want:      50,
+		},
+		{
+			name:      "caret past the left edge",
+			scrollX:   80,
+			caretX:    20,
+			textWidth: 200,
+			width:     100,
+			want:      20,
+		},
+		{
+			name:      "caret visible",
+			scrollX:   40,
+			caretX:    100,
+			textWidth: 200,
+			width:     100,
+			want:      40,
+		},
+		{
+			name:      "text shrunk",
+			scrollX:   100,
+			caretX:    120,
+			textWidth: 120,
+			width:     100,
+			want:      21,
+		},
+		{
+			name:      "text cleared",
+			scrollX:   100,
+			caretX:    0,
+			textWidth: 0,
+			width:     100,
+			want:      0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := textField{scrollX: tc.scrollX}
+			f.scrollToCaret(tc.caretX, tc.textWidth, tc.width)
+			if f.scrollX != tc.want {
+				t.Errorf("scrollX: got %d, want %d", f.scrollX, tc.want)
+			}
+		})
+	}
+}
+
+func TestTextIndexAt(t *testing.T) {
+	c := newTestUI(newTestInput()).ctx
+	for _, str := range []string{"", "a", "hello", "aあb", "i\xffj"} {
+		w := c.textWidth(str)
+		for x := -1; x <= w+2; x++ {
+			// the closest boundary measured by the prefixes of the text. A tie goes to the later boundary.
+			want, best := 0, abs(x)
+			for i := 1; i <= len(str); i++ {
+				if i < len(str) && !utf8.RuneStart(str[i]) {
+					continue
+				}
+				if d := abs(x - c.textWidth(str[:i])); d <= best {
+					want, best = i, d
+				}
+			}
+			if got := c.textIndexAt(str, x); got != want {
+				t.Errorf("textIndexAt(%q, %d) = %d, want %d", str, x, got, want)
+			}
+		}
+	}
+}
+
+func abs(x int) int {
+	return max(x, -x)
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"image/color"
+	"strconv"
+)
+
+// tooltipOffset is the offset of a tooltip from the mouse cursor.
+const tooltipOffset = 16
+
+// tooltipLine is a line of a tooltip.
+type tooltipLine struct {
+	// start and end are the range of the text in the tooltip text of the frame.
+	start, end int
+
+	// clr is the color of the swatch before the text. If clr is transparent, no swatch is drawn.
+	clr color.RGBA
+}
+
+// addTooltip adds a line to the tooltip shown near the mouse cursor in this frame.
+func (c *Context) addTooltip(text string, clr color.RGBA) {
+	start := len(c.tooltipText)
+	c.tooltipText = append(c.tooltipText, text...)
+	c.tooltip = append(c.tooltip, tooltipLine{start: start, end: len(c.tooltipText), clr: clr})
+}
+
+// addTooltipValue adds a line of the label and the value to the tooltip.
+// The text of all the lines is kept in one buffer, so formatting the value doesn't allocate.
+func (c *Context) addTooltipValue(label string, value float64, clr color.RGBA) {
+	start := len(c.tooltipText)
+	c.tooltipText = append(c.tooltipText, label...)
+	c.tooltipText = append(c.tooltipText, ": "...)
+	c.tooltipText = strconv.AppendFloat(c.tooltipText, value, 'g', 6, 64)
+	c.tooltip = append(c.tooltip, tooltipLine{start: start, end: len(c.tooltipText), clr: clr})
+}
+
+// drawTooltip draws the tooltip on top of all the root containers.
+func (c *Context) drawTooltip() {
+	if len(c.tooltip) == 0 {
+		return
+	}
+
+	// the lines share one string, which is allocated only when a tooltip is shown.
+	text := string(c.tooltipText)
+
+	lh := c.lineHeight()
+	var w int
+	for _, l := range c.tooltip {
+		lw := c.textWidth(text[l.start:l.end])
+		if l.clr.A != 0 {
+			lw += lh + c.style.spacing
+		}
+		w = max(w, lw)
+	}
+	p := c.mousePos.Add(image.Pt(tooltipOffset, tooltipOffset))
+	rect := image.Rect(p.X, p.Y, p.X+w+c.style.padding*2, p.Y+len(c.tooltip)*lh+c.style.padding*2)
+
+	c.clipStack = append(c.clipStack, unclippedRect)
+	defer c.popClipRect()
+
+	c.drawFrame(rect, ColorWindowBG)
+	for i, l := range c.tooltip {
+		x := rect.Min.X + c.style.padding
+		y := rect.Min.Y + c.style.padding + i*lh
+		if l.clr.A != 0 {
+			c.drawRect(image.Rect(x, y, x+lh, y+lh).Inset(2), l.clr)
+			x += lh + c.style.spacing
+		}
+		c.drawText(text[l.start:l.end], image.Pt(x, y), c.style.colors[ColorText])
+	}
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// TouchInputSource is an InputSource that also provides touches.
+//
+// Touches are used only when the input source implements TouchInputSource.
+// A tap acts as a left click, a drag acts as a left-button drag, a long press acts as a right click,
+// and a two-finger drag scrolls.
+type TouchInputSource interface {
+	InputSource
+
+	// AppendTouchIDs appends the current touch IDs to touches and returns the extended slice.
+	AppendTouchIDs(touches []ebiten.TouchID) []ebiten.TouchID
+
+	// TouchPosition returns the position of the touch.
+	TouchPosition(id ebiten.TouchID) (x, y int)
+}
+
+const (
+	// touchSlop is the distance in pixels a touch can move and still be a tap or a long press.
+	touchSlop = 8
+
+	// touchLongPressTicks is the number of ticks a touch has to be held to be a long press.
+	touchLongPressTicks = 30
+
+	// touchHitExtent is the number of pixels the hit areas of small handles grow by while touch is active.
+	touchHitExtent = 8
+)
+
+const (
+	touchStateIdle = iota
+	touchStatePending
+	touchStateTap
+	touchStateDrag
+	touchStateLongPress
+	touchStateConsumed
+	touchStateScroll
+)
+
+type touchInput struct {
+	state     int
+	ids       []ebiten.TouchID
+	count     int
+	startPos  image.Point
+	startTick int
+	pos       image.Point
+	left      bool
+	right     bool
+}
+
+func (c *Context) touchPosition(src TouchInputSource, idx int) image.Point {
+	x, y := src.TouchPosition(c.touch.ids[idx])
+	return image.Pt(x, y)
+}
+
+// updateTouch maps the touches onto the mouse state.
+// updateTouch returns false if touch is not in use this frame.
+func (c *Context) updateTouch(src TouchInputSource) bool {
+	t := &c.touch
+	t.ids = src.AppendTouchIDs(t.ids[:0])
+	t.left = false
+	t.right = false
+
+	// the anchor of scrolling is reset when a touch is added or removed, so that the center doesn't jump.
+	n := len(t.ids)
+	countChanged := n != t.count
+	t.count = n
+
+	switch {
+	case n >= 2:
+		p := c.touchPosition(src, 0).Add(c.touchPosition(src, 1)).Div(2)
+		if t.state == touchStateScroll && !countChanged {
+			c.inputScroll(t.pos.X-p.X, t.pos.Y-p.Y)
+		}
+		t.state = touchStateScroll
+		t.pos = p
+	case n == 1:
+		p := c.touchPosition(src, 0)
+		switch t.state {
+		case touchStateIdle, touchStateTap:
+			t.state = touchStatePending
+			t.startPos = p
+			t.startTick = c.tick
+			t.pos = p
+		case touchStatePending:
+			if d := p.Sub(t.startPos); d.X*d.X+d.Y*d.Y > touchSlop*touchSlop {
+				// press at the start position first so that the drag doesn't jump.
+				t.state = touchStateDrag
+				t.left = true
+			} else if c.tick-t.startTick >= touchLongPressTicks {
+				t.state = touchStateLongPress
+				t.right = true
+			}
+		case touchStateDrag:
+			t.pos = p
+			t.left = true
+		case touchStateLongPress:
+			// release the right button after one frame
+			t.state = touchStateConsumed
+		}
+	default:
+		switch t.state {
+		case touchStateIdle:
+			return false
+		case touchStatePending:
+			// press on release and release in the next frame
+			t.state = touchStateTap
+			t.left = true
+		default:
+			t.state = touchStateIdle
+		}
+	}
+
+	c.touchActive = true
+	return true
+}
+
+// isTouchButtonPressed reports whether the touch acts as the mouse button being pressed.
+func (c *Context) isTouchButtonPressed(button ebiten.MouseButton) bool {
+	switch button {
+	case ebiten.MouseButtonLeft:
+		return c.touch.left
+	case ebiten.MouseButtonRight:
+		return c.touch.right
+	}
+	return false
+}
+
+// hitExtent returns the number of pixels the hit areas of small handles grow by.
+func (c *Context) hitExtent() int {
+	if c.touchActive {
+		return touchHitExtent
+	}
+	return 0
+}
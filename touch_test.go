@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2024 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// testTouchInput is a TouchInputSource whose touches are set by tests.
+type testTouchInput struct {
+	testInput
+	touches map[ebiten.TouchID]image.Point
+}
+
+func (t *testTouchInput) AppendTouchIDs(touches []ebiten.TouchID) []ebiten.TouchID {
+	start := len(touches)
+	for id := range t.touches {
+		touches = append(touches, id)
+	}
+	slices.Sort(touches[start:])
+	return touches
+}
+
+func (t *testTouchInput) TouchPosition(id ebiten.TouchID) (x, y int) {
+	p := t.touches[id]
+	return p.X, p.Y
+}
+
+func TestTouchScrollAnchor(t *testing.T) {
+	input := &testTouchInput{
+		testInput: *newTestInput(),
+	}
+	c := newTestUI(input).ctx
+
+	testCases := []struct {
+		name    string
+		touches map[ebiten.TouchID]image.Point
+		want    image.Point
+	}{
+		{
+			name:    "two touches",
+			touches: map[ebiten.TouchID]image.Point{1: {100, 100}, 2: {120, 100}},
+			want:    image.Point{},
+		},
+		{
+			name:    "drag",
+			touches: map[ebiten.TouchID]image.Point{1: {100, 90}, 2: {120, 90}},
+			want:    image.Pt(0, 10),
+		},
+		{
+			name:    "touch added",
+			touches: map[ebiten.TouchID]image.Point{0: {0, 0}, 1: {100, 90}, 2: {120, 90}},
+			want:    image.Point{},
+		},
+		{
+			name:    "drag with three touches",
+			touches: map[ebiten.TouchID]image.Point{0: {10, 0}, 1: {110, 90}, 2: {130, 90}},
+			want:    image.Pt(-10, 0),
+		},
+		{
+			name:    "touch removed",
+			touches: map[ebiten.TouchID]image.Point{1: {110, 90}, 2: {130, 90}},
+			want:    image.Point{},
+		},
+		{
+			name:    "one touch",
+			touches: map[ebiten.TouchID]image.Point{2: {130, 90}},
+			want:    image.Point{},
+		},
+		{
+			name:    "two touches again",
+			touches: map[ebiten.TouchID]image.Point{2: {130, 90}, 3: {300, 300}},
+			want:    image.Point{},
+		},
+	}
+	for _, tc := range testCases {
+		input.touches = tc.touches
+		c.scrollDelta = image.Point{}
+		c.updateTouch(input)
+		if got := c.scrollDelta; got != tc.want {
+			t.Errorf("%s: scroll: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
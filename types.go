@@ -4,11 +4,12 @@
 package debugui
 
 import (
+	"hash/maphash"
 	"image"
 	"image/color"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/exp/textinput"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
 )
 
 type ID uint64
@@ -18,39 +19,6 @@ type poolItem struct {
 	lastUpdate int
 }
 
-type baseCommand struct {
-	typ int
-}
-
-type jumpCommand struct {
-	dstIdx int
-}
-
-type clipCommand struct {
-	rect image.Rectangle
-}
-
-type rectCommand struct {
-	rect  image.Rectangle
-	color color.Color
-}
-
-type textCommand struct {
-	pos   image.Point
-	color color.Color
-	str   string
-}
-
-type iconCommand struct {
-	rect  image.Rectangle
-	icon  icon
-	color color.Color
-}
-
-type drawCommand struct {
-	f func(screen *ebiten.Image)
-}
-
 type layout struct {
 	body      image.Rectangle
 	position  image.Point
@@ -62,16 +30,39 @@ type layout struct {
 	indent    int
 }
 
+// command is a drawing command in the command list.
+// The command list is reused every frame, so a command is stored as a value and has only the fields shared by the types.
 type command struct {
-	typ  int
-	idx  int
-	base baseCommand // type 0 (TODO)
-	jump jumpCommand // type 1
-	clip clipCommand // type 2
-	rect rectCommand // type 3
-	text textCommand // type 4
-	icon iconCommand // type 5
-	draw drawCommand // type 6
+	typ int
+	idx int
+
+	// rect is the rectangle of a clip, rect or icon command, or the position of a text command at rect.Min.
+	rect image.Rectangle
+
+	// color is the color of a rect, text or icon command.
+	color color.RGBA
+
+	// icon is the icon of an icon command.
+	icon Icon
+
+	// radius is the corner radius of a rounded rect, outline or shadow command.
+	radius int
+
+	// width is the border width of an outline command, or the size of a shadow command.
+	width int
+
+	// dstIdx is the destination index of a jump command.
+	dstIdx int
+
+	// str is the text of a text command.
+	str string
+
+	// font and face are the font and its face of a text command.
+	font Font
+	face text.Face
+
+	// f is the function of a draw command.
+	f func(screen *ebiten.Image)
 }
 
 type container struct {
@@ -80,6 +71,12 @@ type container struct {
 	tailIdx int
 	zIndex  int
 	open    bool
+
+	// opacity is the opacity of the root container. 0 means it is not initialized yet.
+	opacity float64
+
+	// fade is how much the root container is faded out, from 0 to 1.
+	fade float64
 }
 
 type Layout struct {
@@ -97,6 +94,9 @@ type style struct {
 	titleHeight   int
 	scrollbarSize int
 	thumbSize     int
+	cornerRadius  int
+	borderWidth   int
+	shadowSize    int
 	colors        [ColorMax + 1]color.RGBA
 }
 
@@ -116,15 +116,45 @@ type Context struct {
 	scrollTarget  *container
 	numberEditBuf string
 	numberEdit    ID
+	alpha         float64
+
+	// comboHighlight is the highlighted item in the open popup of a combo box.
+	comboHighlight int
+
+	// colorPickers is the HSV state of each color picker.
+	colorPickers map[ID]*colorPickerState
+
+	// tooltip is the lines of the tooltip drawn at the end of the frame, and tooltipText is their text.
+	tooltip     []tooltipLine
+	tooltipText []byte
+
+	// plotSeries, barSeries and seriesValues are reused by the plots and the charts in a frame.
+	plotSeries   []PlotSeries
+	barSeries    []BarSeries
+	seriesValues [][]float64
+
+	// navFocus is the control with the keyboard focus.
+	// navFocus is set only by the keyboard or gamepad navigation, not by clicks.
+	navFocus     ID
+	keepNavFocus bool
+	navRoot      *container
+	frontRoot    *container
 
 	// stacks
 
-	commandList    []*command
+	commandList    []command
+	commandIter    CommandIterator
+	overlayIdx     int
+	dropped        command
+	droppedCount   int
 	rootList       []*container
 	containerStack []*container
 	clipStack      []image.Rectangle
 	idStack        []ID
 	layoutStack    []layout
+	fontStack      []Font
+	navIDs         []ID
+	navRects       []image.Rectangle
 
 	// retained state pools
 
@@ -132,6 +162,10 @@ type Context struct {
 	containers    [containerPoolSize]container
 	treeNodePool  [treeNodePoolSize]poolItem
 
+	// listBoxAnchors is the last clicked item of each multi-select list box in listBoxPool for Shift-click.
+	listBoxPool    [listBoxPoolSize]poolItem
+	listBoxAnchors [listBoxPoolSize]int
+
 	// input state
 
 	mousePos     image.Point
@@ -142,6 +176,49 @@ type Context struct {
 	mousePressed int
 	keyDown      int
 	keyPressed   int
-
-	textFields map[ID]*textinput.Field
+	keyDownTicks [keyCount]int
+	inputChars   []rune
+	navPressed   int
+
+	gamepadMode       GamepadMode
+	gamepadDown       int
+	virtualCursor     [2]float64
+	virtualCursorInit bool
+
+	touch           touchInput
+	touchActive     bool
+	lastInputCursor image.Point
+
+	inputFrame inputFrame
+	recorder   *InputRecorder
+	player     *InputPlayer
+
+	// ime reports whether the text is input through the IME, i.e., the input is read from Ebitengine.
+	ime bool
+
+	input      InputSource
+	transform  ebiten.GeoM
+	invertible bool
+	inverse    ebiten.GeoM
+
+	// transformed reports whether a transform is set by SetTransform.
+	transformed bool
+
+	// screenBounds is the bounds of the screen in the UI coordinates, updated by Draw.
+	screenBounds image.Rectangle
+
+	// scale is the scale factor set by SetScale. 0 means the device scale factor.
+	scale         float64
+	deviceScale   float64
+	noDeviceScale bool
+
+	offscreen *ebiten.Image
+	renderer  EbitenRenderer
+
+	windowCaches   map[*container]*windowCache
+	commandHash    maphash.Hash
+	commandHashBuf []byte
+	clipboard      Clipboard
+	textFields     map[ID]*textField
+	faces          [fontCount]text.Face
 }
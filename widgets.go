@@ -3,7 +3,10 @@
 
 package debugui
 
-import "image"
+import (
+	"image"
+	"image/color"
+)
 
 func (c *Context) Button(label string) Response {
 	return c.buttonEx(label, optionAlignCenter)
@@ -34,9 +37,123 @@ func (c *Context) TreeNode(label string, f func(res Response)) {
 }
 
 func (c *Context) Window(title string, rect image.Rectangle, f func(res Response, layout Layout)) {
-	c.window(title, rect, 0, f)
+	c.window(title, rect, 0, 0, f)
+}
+
+// WindowOptions represents options for WindowWithOptions.
+type WindowOptions struct {
+	// Opacity is the initial opacity of the window from 0 to 1.
+	// The opacity is applied to everything in the window including the custom drawing functions.
+	// The opacity is applied to each drawing separately, so where the drawings overlap, e.g. a control on the window background,
+	// they are blended with each other and the window is not uniformly translucent.
+	//
+	// If Opacity is 0, the window is opaque.
+	Opacity float64
+
+	// OpacityControl shows a control in the title bar to adjust the opacity by dragging.
+	OpacityControl bool
+
+	// FadeWhenNotHovered makes the window fade out while the mouse cursor is not over the window.
+	FadeWhenNotHovered bool
+}
+
+// WindowWithOptions is like Window but with the options.
+// options can be nil, and then the default options are used.
+//
+// Like rect, the opacity in options is used only when the window is shown for the first time.
+func (c *Context) WindowWithOptions(title string, rect image.Rectangle, options *WindowOptions, f func(res Response, layout Layout)) {
+	if options == nil {
+		options = &WindowOptions{}
+	}
+	var opt option
+	if options.OpacityControl {
+		opt |= optionOpacityControl
+	}
+	if options.FadeWhenNotHovered {
+		opt |= optionFadeWhenNotHovered
+	}
+	c.window(title, rect, options.Opacity, opt, f)
+}
+
+// ComboBox shows the item at *index of items and opens a popup under the control to select another item.
+// The arrow keys change the selection, and Enter or Space opens the popup.
+//
+// ComboBox returns ResponseChange when the selection is changed.
+func (c *Context) ComboBox(label string, index *int, items []string) Response {
+	return c.comboBox(label, index, items, 0)
 }
 
 func (c *Context) Panel(name string, f func(layout Layout)) {
 	c.panel(name, 0, f)
 }
+
+// ListBox shows the items in a scrollable panel and lets the user select one of them.
+// *index is the selected item, or -1 if no item is selected.
+//
+// Only the visible items are laid out and drawn, so ListBox can show thousands of items.
+// The height of the panel is the height of the layout row.
+//
+// ListBox returns ResponseChange when the selection is changed.
+func (c *Context) ListBox(name string, items []string, index *int) Response {
+	return c.listBox(name, items, index, nil, false, 0)
+}
+
+// MultiSelectListBox is like ListBox but lets the user select multiple items.
+// selected is the set of the selected items owned by the caller.
+// selected[i] is true when the item i is selected, and the unselected items are deleted from selected.
+// MultiSelectListBox panics if selected is nil.
+//
+// Click selects an item, Ctrl-click toggles an item, and Shift-click selects a range of the items.
+func (c *Context) MultiSelectListBox(name string, items []string, selected map[int]bool) Response {
+	return c.listBox(name, items, nil, selected, true, 0)
+}
+
+// ColorEdit shows the color with its hex code, and opens a ColorPicker in a popup when it is clicked.
+// clr is premultiplied as color.RGBA is, while the hex code is not premultiplied.
+//
+// ColorEdit returns ResponseChange when the color is changed.
+func (c *Context) ColorEdit(label string, clr *color.RGBA) Response {
+	return c.colorEdit(label, clr, 0)
+}
+
+// ColorPicker lets the user edit the color with a saturation/value square, a hue bar, an alpha bar,
+// and a hex text entry in the form of #RRGGBBAA.
+// clr is premultiplied as color.RGBA is, while the hex code is not premultiplied.
+//
+// ColorPicker takes a cell of the current layout row, and is as tall as needed.
+//
+// ColorPicker returns ResponseChange when the color is changed.
+func (c *Context) ColorPicker(clr *color.RGBA) Response {
+	return c.colorPicker(clr)
+}
+
+// PlotLines draws the values as a line from the oldest to the newest.
+// If options is nil, the default options are used.
+//
+// The vertical range is computed from the values unless options specifies it.
+// When the mouse cursor is over the plot, the values at the cursor are shown in a tooltip.
+// The other series in options are drawn together with a legend.
+//
+// PlotBuffer is useful to keep the latest values pushed every Update.
+func (c *Context) PlotLines(label string, values []float64, options *PlotOptions) {
+	c.plotLines(label, values, options)
+}
+
+// BarChart draws a bar for each of the values with the label of the same index.
+// If options is nil, the default options are used.
+//
+// The other series in options are stacked on the values and shown in a legend.
+// When the mouse cursor is over a bar, the values of the bar are shown in a tooltip.
+func (c *Context) BarChart(label string, labels []string, values []float64, options *BarChartOptions) {
+	c.barChart(label, labels, values, options)
+}
+
+// Histogram draws the number of the samples in each of the buckets as a bar.
+// The buckets divide the range of the samples evenly.
+// If options is nil, the default options are used.
+//
+// The values of the other series in options are also treated as samples,
+// and bucketed in the same buckets as samples.
+func (c *Context) Histogram(label string, samples []float64, buckets int, options *BarChartOptions) {
+	c.histogram(label, samples, buckets, options)
+}